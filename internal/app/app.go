@@ -2,12 +2,14 @@ package app
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"sync-tool/internal/config"
 	"sync-tool/internal/logger"
+	"sync-tool/internal/state"
 	"sync-tool/internal/sync"
 	"sync-tool/internal/ui"
 )
@@ -21,11 +23,23 @@ func ShowStatus(cfg *config.Config) error {
 	fmt.Printf("설정된 프로필: %d개\n", len(cfg.Profiles))
 	fmt.Println()
 
+	syncEngine := sync.NewSyncEngine(cfg)
+
 	// 각 프로필별 상태 확인
 	for name, profile := range cfg.Profiles {
 		fmt.Printf("프로필: %s\n", name)
 		fmt.Printf("  설명: %s\n", profile.Description)
 		fmt.Printf("  서버 경로: %s\n", profile.ServerPath)
+
+		// 대상 장치 확인
+		if profile.Device.IsSet() {
+			fmt.Printf("  대상 장치: %s\n", profile.Device)
+			if err := resolveTarget(&profile); err != nil {
+				fmt.Printf("  상태: ❌ %v\n", err)
+				fmt.Println()
+				continue
+			}
+		}
 		fmt.Printf("  로컬 경로: %s\n", profile.LocalPath)
 
 		// 로컬 경로 존재 여부 확인
@@ -34,6 +48,16 @@ func ShowStatus(cfg *config.Config) error {
 		} else {
 			fmt.Printf("  상태: ✅ 로컬 경로 존재\n")
 		}
+
+		// 캐시 상태 확인
+		if info, fresh := syncEngine.CacheStatus(&profile); info != nil {
+			state := "사용 가능"
+			if !fresh {
+				state = "만료됨"
+			}
+			fmt.Printf("  캐시: %s (%s, fetch 시각: %s)\n",
+				state, config.FormatSize(info.Size), info.FetchedAt.Format("2006-01-02 15:04:05"))
+		}
 		fmt.Println()
 	}
 
@@ -48,15 +72,36 @@ func ShowProfiles(cfg *config.Config) error {
 		fmt.Printf("• %s\n", name)
 		fmt.Printf("  %s\n", profile.Description)
 		fmt.Printf("  서버: %s\n", profile.ServerPath)
-		fmt.Printf("  로컬: %s\n", profile.LocalPath)
+		fmt.Printf("  로컬: %s\n", targetKey(&profile))
+		fmt.Println()
+	}
+
+	if len(cfg.Groups) > 0 {
+		fmt.Println("=== 프로필 그룹 ===")
+		for group, members := range cfg.Groups {
+			fmt.Printf("• %s: %s\n", group, strings.Join(members, ", "))
+		}
 		fmt.Println()
 	}
 
 	return nil
 }
 
+// SyncOptions 동기화 실행 옵션
+type SyncOptions struct {
+	DryRun      bool
+	AutoConfirm bool
+	NoCache     bool
+	Targets     []string
+	Jobs        int
+	All         bool
+	Group       string
+	BWLimit     string
+	Resume      bool
+}
+
 // Sync 파일 동기화 실행
-func Sync(cfg *config.Config, profileName string, dryRun bool, autoConfirm bool) error {
+func Sync(cfg *config.Config, profileName string, opts SyncOptions) error {
 	// 로거 초기화
 	if err := logger.Init(&cfg.Logging); err != nil {
 		return fmt.Errorf("로거 초기화 실패: %w", err)
@@ -64,17 +109,35 @@ func Sync(cfg *config.Config, profileName string, dryRun bool, autoConfirm bool)
 
 	logger.Info("동기화 시작")
 
+	// Ctrl-C를 받으면 새 작업을 시작하지 않고 진행 중인 전송을 정리
+	ctx, stop := interruptContext()
+	defer stop()
+
+	// 전체 또는 그룹 단위 동기화
+	if opts.All || opts.Group != "" {
+		if profileName != "" {
+			return fmt.Errorf("프로필명과 --all/--group은 함께 사용할 수 없습니다")
+		}
+		if opts.Resume {
+			return fmt.Errorf("--resume은 프로필 하나에만 사용할 수 있습니다")
+		}
+		if len(opts.Targets) > 0 {
+			return fmt.Errorf("--targets는 --all/--group과 함께 사용할 수 없습니다")
+		}
+		return syncProfiles(ctx, cfg, opts)
+	}
+
 	// 프로필 선택
 	var selectedProfile *config.SyncProfile
 	if profileName != "" {
-		profile, exists := cfg.Profiles[profileName]
-		if !exists {
-			return fmt.Errorf("프로필을 찾을 수 없습니다: %s", profileName)
+		profile, err := findProfile(cfg, profileName)
+		if err != nil {
+			return err
 		}
-		selectedProfile = &profile
+		selectedProfile = profile
 	} else {
 		// 대화형 프로필 선택
-		profile, err := selectProfileInteractively(cfg)
+		profile, err := selectProfileInteractively(ctx, cfg)
 		if err != nil {
 			return fmt.Errorf("프로필 선택 실패: %w", err)
 		}
@@ -83,19 +146,62 @@ func Sync(cfg *config.Config, profileName string, dryRun bool, autoConfirm bool)
 
 	logger.Infof("선택된 프로필: %s", selectedProfile.Name)
 
+	// 여러 대상 장치로 동시 동기화
+	if len(opts.Targets) > 0 {
+		if opts.Resume {
+			return fmt.Errorf("--resume은 --targets와 함께 사용할 수 없습니다")
+		}
+		return syncTargets(ctx, cfg, selectedProfile, opts)
+	}
+
+	// 대상 장치 확인
+	if err := resolveTarget(selectedProfile); err != nil {
+		return err
+	}
+
 	// 동기화 엔진 생성
 	syncEngine := sync.NewSyncEngine(cfg)
+	syncEngine.SetUseCache(!opts.NoCache)
+	syncEngine.SetBandwidthLimit(opts.BWLimit)
 
 	// 프로필 유효성 검사
 	if err := syncEngine.ValidateProfile(selectedProfile); err != nil {
 		return fmt.Errorf("프로필 유효성 검사 실패: %w", err)
 	}
 
-	// 드라이런 실행
-	logger.Info("변경사항 확인 중...")
-	changes, err := syncEngine.DryRun(selectedProfile)
-	if err != nil {
-		return fmt.Errorf("드라이런 실행 실패: %w", err)
+	// 중단된 동기화 재개 또는 드라이런 실행
+	var changes *sync.SyncResult
+	if opts.Resume {
+		journal, err := state.OpenJournal(cfg.Sync.StateDir, selectedProfile.ID, selectedProfile.LocalPath)
+		if err != nil {
+			return err
+		}
+		if journal == nil {
+			return fmt.Errorf("재개할 동기화 기록이 없습니다: %s (%s)", selectedProfile.ID, selectedProfile.LocalPath)
+		}
+		defer journal.Close()
+
+		changes = sync.ResumeResult(journal)
+		syncEngine.SetJournal(journal)
+		fmt.Printf("⏯️  %s에 시작한 동기화를 재개합니다 (복사 완료 %d/%d개, 삭제 완료 %d/%d개)\n",
+			journal.Plan.Created.Format("2006-01-02 15:04:05"),
+			len(journal.Completed), len(journal.Plan.Changes), len(journal.Deleted), len(journal.Plan.Deletions))
+
+		if !changes.HasChanges && !changes.HasDeletions {
+			fmt.Println("✅ 남은 작업이 없습니다.")
+			return journal.Remove()
+		}
+	} else {
+		if _, err := os.Stat(state.JournalPath(cfg.Sync.StateDir, selectedProfile.ID, selectedProfile.LocalPath)); err == nil {
+			fmt.Println("⚠️  중단된 이전 동기화가 있습니다. --resume으로 이어서 실행할 수 있습니다.")
+		}
+
+		logger.Info("변경사항 확인 중...")
+		plan, err := planProfile(ctx, cfg, syncEngine, selectedProfile, os.Stdout)
+		if err != nil {
+			return err
+		}
+		changes = plan
 	}
 
 	// 변경사항 표시
@@ -108,14 +214,18 @@ func Sync(cfg *config.Config, profileName string, dryRun bool, autoConfirm bool)
 	}
 
 	// 드라이런 모드인 경우 여기서 종료
-	if dryRun {
+	if opts.DryRun {
 		fmt.Println("드라이런 모드로 실행되었습니다. 실제 동기화는 수행되지 않았습니다.")
 		return nil
 	}
 
 	// 사용자 확인
-	if !autoConfirm && cfg.UI.ConfirmActions {
-		if !confirmSync(changes) {
+	if !opts.AutoConfirm && cfg.UI.ConfirmActions {
+		confirmed, err := confirmSync(ctx, changes)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
 			fmt.Println("동기화가 취소되었습니다.")
 			return nil
 		}
@@ -123,16 +233,61 @@ func Sync(cfg *config.Config, profileName string, dryRun bool, autoConfirm bool)
 
 	// 실제 동기화 실행
 	logger.Info("동기화 실행 중...")
-	if err := syncEngine.Sync(selectedProfile, changes); err != nil {
-		return fmt.Errorf("동기화 실행 실패: %w", err)
+	if err := applyPlan(ctx, cfg, syncEngine, selectedProfile, changes, os.Stdout); err != nil {
+		// 같은 명령에 --resume을 붙이면 남은 저널로 이어서 실행할 수 있음
+		if _, statErr := os.Stat(state.JournalPath(cfg.Sync.StateDir, selectedProfile.ID, selectedProfile.LocalPath)); statErr == nil {
+			fmt.Printf("💡 중단된 지점부터 이어서 실행하려면: sync-tool sync %s --resume\n", selectedProfile.ID)
+		}
+		return err
 	}
 
 	fmt.Println("✅ 동기화가 완료되었습니다.")
 	return nil
 }
 
+// FetchOptions fetch 명령 옵션
+type FetchOptions struct {
+	BWLimit string // 이번 실행의 대역폭 제한 (비우면 transfer.bwlimit)
+}
+
+// Fetch 서버 경로를 로컬 캐시로 미러링
+func Fetch(cfg *config.Config, profileName string, opts FetchOptions) error {
+	// 로거 초기화
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	syncEngine := sync.NewSyncEngine(cfg)
+	syncEngine.SetBandwidthLimit(opts.BWLimit)
+	info, err := syncEngine.Fetch(ctx, profile)
+	if err != nil {
+		return fmt.Errorf("fetch 실행 실패: %w", err)
+	}
+
+	fmt.Printf("✅ 캐시 fetch가 완료되었습니다: %s (%s)\n", profile.ID, config.FormatSize(info.Size))
+	fmt.Printf("   이후 '%s' 프로필 동기화는 캐시에서 복사합니다.\n", profile.ID)
+	return nil
+}
+
+// findProfile 이름으로 프로필 조회
+func findProfile(cfg *config.Config, profileName string) (*config.SyncProfile, error) {
+	profile, exists := cfg.Profiles[profileName]
+	if !exists {
+		return nil, fmt.Errorf("프로필을 찾을 수 없습니다: %s", profileName)
+	}
+	return &profile, nil
+}
+
 // selectProfileInteractively 대화형 프로필 선택
-func selectProfileInteractively(cfg *config.Config) (*config.SyncProfile, error) {
+func selectProfileInteractively(ctx context.Context, cfg *config.Config) (*config.SyncProfile, error) {
 	fmt.Println("동기화할 프로필을 선택하세요:")
 	fmt.Println()
 
@@ -143,7 +298,7 @@ func selectProfileInteractively(cfg *config.Config) (*config.SyncProfile, error)
 	for name, profile := range cfg.Profiles {
 		fmt.Printf("%d) %s - %s\n", i, name, profile.Description)
 		fmt.Printf("   서버: %s\n", profile.ServerPath)
-		fmt.Printf("   로컬: %s\n", profile.LocalPath)
+		fmt.Printf("   로컬: %s\n", targetKey(&profile))
 		fmt.Println()
 
 		profiles = append(profiles, profile)
@@ -152,8 +307,10 @@ func selectProfileInteractively(cfg *config.Config) (*config.SyncProfile, error)
 	}
 
 	fmt.Print("번호 선택: ")
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	input, err := readLine(ctx)
+	if interrupted(err) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("입력 읽기 실패: %w", err)
 	}
@@ -177,6 +334,9 @@ func showChanges(changes *sync.SyncResult) {
 	fmt.Println("=== 변경사항 요약 ===")
 	fmt.Printf("복사할 파일: %d개\n", len(changes.Changes))
 	fmt.Printf("삭제할 파일: %d개\n", len(changes.Deletions))
+	if changes.TotalBytes > 0 {
+		fmt.Printf("전송 크기: %s\n", config.FormatSize(changes.TotalBytes))
+	}
 	fmt.Println()
 
 	// 복사할 파일 목록
@@ -202,17 +362,45 @@ func showChanges(changes *sync.SyncResult) {
 }
 
 // confirmSync 동기화 확인
-func confirmSync(changes *sync.SyncResult) bool {
-	fmt.Print("위 파일들을 동기화하시겠습니까? (y/n): ")
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+func confirmSync(ctx context.Context, changes *sync.SyncResult) (bool, error) {
+	return askYesNo(ctx, "위 파일들을 동기화하시겠습니까? (y/n): ")
+}
+
+// askYesNo 예/아니오 질문 (답을 기다리는 중에 중단 요청을 받으면 바로 중단 오류 반환)
+func askYesNo(ctx context.Context, prompt string) (bool, error) {
+	fmt.Print(prompt)
+	input, err := readLine(ctx)
+	if interrupted(err) {
+		return false, err
+	}
 	if err != nil {
 		logger.Errorf("입력 읽기 실패: %v", err)
-		return false
+		return false, nil
 	}
 
 	input = strings.ToLower(strings.TrimSpace(input))
-	return input == "y" || input == "yes"
+	return input == "y" || input == "yes", nil
+}
+
+// readLine 표준 입력에서 한 줄 읽기 (ctx가 취소되면 Enter를 기다리지 않고 반환)
+func readLine(ctx context.Context) (string, error) {
+	type result struct {
+		line string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		done <- result{line, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.line, r.err
+	case <-ctx.Done():
+		fmt.Println()
+		return "", fmt.Errorf("확인 중 중단됨: %w", ctx.Err())
+	}
 }
 
 // getChangeIcon 변경 타입에 따른 아이콘 반환
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/state"
+	"sync-tool/internal/sync"
+)
+
+// CleanOptions clean 명령 옵션
+type CleanOptions struct {
+	OlderThan string // 이 기간 동안 갱신되지 않은 항목만 삭제
+	DryRun    bool   // 삭제하지 않고 대상만 표시
+}
+
+// Clean 오래된 동기화 저널과 부분 전송/스테이징 디렉토리, 임시 파일 목록 정리
+func Clean(cfg *config.Config, profileName string, opts CleanOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	olderThan, err := config.ParseDuration(opts.OlderThan)
+	if err != nil {
+		return err
+	}
+
+	ids := cfg.ProfileIDs()
+	if profileName != "" {
+		if _, err := findProfile(cfg, profileName); err != nil {
+			return err
+		}
+		ids = []string{profileName}
+	}
+
+	action := "삭제"
+	if opts.DryRun {
+		action = "삭제 예정"
+	}
+	stale := func(modTime time.Time) bool {
+		return time.Since(modTime) >= olderThan
+	}
+	remove := func(path string) {
+		if opts.DryRun {
+			return
+		}
+		if err := os.RemoveAll(path); err != nil {
+			logger.Warnf("정리 실패: %s: %v", path, err)
+		}
+	}
+
+	fmt.Println("=== 정리 ===")
+
+	// 1. 저널 (최근 것은 --resume을 위해 남기고, 그 대상의 부분 전송 파일도 유지)
+	resumable := make(map[string]bool)
+	journals, err := state.ListJournals(cfg.Sync.StateDir)
+	if err != nil {
+		return err
+	}
+	removedJournals := 0
+	running := false
+	for _, path := range journals {
+		journal, err := state.ReadJournal(path)
+		if err != nil {
+			logger.Warnf("손상된 저널: %v", err)
+			if profileName == "" {
+				fmt.Printf("🗑️  손상된 저널 %s: %s\n", action, path)
+				remove(path)
+				removedJournals++
+			}
+			continue
+		}
+		if profileName != "" && journal.Plan.Profile != profileName {
+			continue
+		}
+		if journal.Active() {
+			running = true
+			resumable[filepath.Clean(journal.Plan.Target)] = true
+			fmt.Printf("🔄 실행 중인 동기화의 저널 유지: %s → %s (PID %d)\n",
+				journal.Plan.Profile, journal.Plan.Target, journal.PID)
+			continue
+		}
+		if !stale(journal.Updated) {
+			resumable[filepath.Clean(journal.Plan.Target)] = true
+			fmt.Printf("⏯️  재개 가능한 저널 유지: %s → %s (%s 갱신)\n",
+				journal.Plan.Profile, journal.Plan.Target, journal.Updated.Format("2006-01-02 15:04:05"))
+			continue
+		}
+		fmt.Printf("🗑️  저널 %s: %s → %s\n", action, journal.Plan.Profile, journal.Plan.Target)
+		remove(path)
+		removedJournals++
+	}
+
+	// 2. 대상 경로의 부분 전송 디렉토리와 스테이징 디렉토리
+	removedPartials := 0
+	var partialBytes int64
+	engine := sync.NewSyncEngine(cfg)
+	for _, id := range ids {
+		profile, _ := findProfile(cfg, id)
+		if err := resolveTarget(profile); err != nil {
+			logger.Debugf("대상을 찾을 수 없어 건너뜀: %v", err)
+			continue
+		}
+		if _, err := os.Stat(profile.LocalPath); err != nil {
+			continue
+		}
+		if resumable[filepath.Clean(profile.LocalPath)] {
+			continue
+		}
+
+		dirs := findPartialDirs(profile.LocalPath, engine.PartialDir(profile))
+		if _, err := os.Stat(filepath.Join(profile.LocalPath, sync.StagingDir)); err == nil {
+			dirs = append(dirs, filepath.Join(profile.LocalPath, sync.StagingDir))
+		}
+		for _, dir := range dirs {
+			info, err := os.Stat(dir)
+			if err != nil || !stale(info.ModTime()) {
+				continue
+			}
+			size, _ := sync.DirSize(dir)
+			fmt.Printf("🗑️  부분 전송 디렉토리 %s: %s (%s)\n", action, dir, config.FormatSize(size))
+			remove(dir)
+			removedPartials++
+			partialBytes += size
+		}
+	}
+
+	// 3. 중단된 실행이 남긴 임시 파일 목록 (실행 중인 동기화가 있으면 사용 중일 수 있으므로 유지)
+	removedLists := 0
+	lists := []string{}
+	if !running {
+		lists = sync.TempLists()
+	}
+	for _, list := range lists {
+		if info, err := os.Stat(list); err == nil && stale(info.ModTime()) {
+			remove(list)
+			removedLists++
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("저널 %d개, 부분 전송 디렉토리 %d개 (%s), 임시 파일 목록 %d개 %s\n",
+		removedJournals, removedPartials, config.FormatSize(partialBytes), removedLists, action)
+	return nil
+}
+
+// findPartialDirs 대상 경로 아래의 부분 전송 디렉토리 목록 (rsync는 파일이 있는 디렉토리마다 생성)
+func findPartialDirs(root, partialDir string) []string {
+	if filepath.IsAbs(partialDir) {
+		if _, err := os.Stat(partialDir); err == nil {
+			return []string{partialDir}
+		}
+		return nil
+	}
+
+	dirs := []string{}
+	filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() && entry.Name() == partialDir {
+			dirs = append(dirs, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return dirs
+}
@@ -0,0 +1,330 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sync-tool/internal/bundle"
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/manifest"
+	"sync-tool/internal/state"
+	"sync-tool/internal/sync"
+)
+
+// CollectOptions collect 명령 옵션
+type CollectOptions struct {
+	Output string // 번들 파일 경로 (tar.gz)
+}
+
+// ApplyBundleOptions apply-bundle 명령 옵션
+type ApplyBundleOptions struct {
+	DryRun      bool
+	AutoConfirm bool
+	Force       bool // 서버 파일이 동기화 이후 바뀌었어도 번들 내용으로 덮어쓰기
+}
+
+// Collect 마지막 동기화 이후 대상에서 추가/수정/삭제된 파일을 검토용 번들로 묶음
+func Collect(cfg *config.Config, profileName string, opts CollectOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+	if opts.Output == "" {
+		return fmt.Errorf("번들 파일 경로를 지정하세요 (-o changes.tar.gz)")
+	}
+
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+	if err := resolveTarget(profile); err != nil {
+		return err
+	}
+	if _, err := os.Stat(profile.LocalPath); err != nil {
+		return fmt.Errorf("로컬 경로가 존재하지 않습니다: %s", profile.LocalPath)
+	}
+
+	base, err := state.LoadSynced(cfg.Sync.StateDir, profile.ID, profile.LocalPath)
+	if err != nil {
+		return err
+	}
+	if base == nil {
+		return fmt.Errorf("%s에 대한 동기화 기록이 없습니다. 먼저 sync로 기준 상태를 기록하세요", profile.LocalPath)
+	}
+
+	engine := sync.NewSyncEngine(cfg)
+	changes, err := localChanges(engine, profile, base)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("=== %s 로컬 변경 (%s 동기화 이후) ===\n", profile.LocalPath, base.Created.Format("2006-01-02 15:04:05"))
+	if len(changes) == 0 {
+		fmt.Println("✅ 마지막 동기화 이후 바뀐 파일이 없습니다.")
+		return nil
+	}
+	showBundleChanges(changes)
+
+	host, _ := os.Hostname()
+	b := &bundle.Bundle{
+		Profile:    profile.ID,
+		ServerPath: profile.ServerPath,
+		Target:     profile.LocalPath,
+		Host:       host,
+		Created:    time.Now(),
+		BaseTime:   base.Created,
+		Changes:    changes,
+	}
+	if err := bundle.Write(opts.Output, b, profile.LocalPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("📦 번들 저장: %s (변경 %d개)\n", opts.Output, len(changes))
+	fmt.Printf("   검토 후 서버에서: sync-tool apply-bundle %s\n", opts.Output)
+	return nil
+}
+
+// ApplyBundle collect로 만든 번들을 서버 트리에 적용 (동기화 이후 서버에서도 바뀐 파일은 충돌로 보고)
+func ApplyBundle(cfg *config.Config, archive, dir string, opts ApplyBundleOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	b, err := bundle.Read(archive)
+	if err != nil {
+		return err
+	}
+	if dir == "" {
+		dir = b.ServerPath
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("서버 트리 경로가 존재하지 않습니다: %s", dir)
+	}
+
+	fmt.Printf("=== 번들: %s 프로필, %s의 %s에서 %s에 수집 ===\n",
+		b.Profile, orDash(b.Host), b.Target, b.Created.Format("2006-01-02 15:04:05"))
+	fmt.Printf("적용 대상: %s\n", dir)
+
+	pending := []bundle.Change{}
+	conflicts := 0
+	for _, change := range b.Changes {
+		status, conflict := bundleStatus(dir, change)
+		switch {
+		case status == "":
+			pending = append(pending, change)
+			fmt.Printf("%s %s\n", bundleIcon(change.Action), change.Path)
+		case conflict:
+			conflicts++
+			if opts.Force {
+				pending = append(pending, change)
+			}
+			fmt.Printf("⚠️  %s: %s\n", change.Path, status)
+		default:
+			fmt.Printf("✔️  %s: %s\n", change.Path, status)
+		}
+	}
+
+	if conflicts > 0 && !opts.Force {
+		return fmt.Errorf("충돌 %d개로 적용하지 않았습니다 (서버 파일을 확인한 뒤 덮어쓰려면 --force)", conflicts)
+	}
+	if len(pending) == 0 {
+		fmt.Println("✅ 적용할 변경이 없습니다.")
+		return nil
+	}
+	if opts.DryRun {
+		fmt.Println("드라이런 모드로 실행되었습니다. 실제로 변경하지 않았습니다.")
+		return nil
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	if !opts.AutoConfirm && cfg.UI.ConfirmActions {
+		confirmed, err := askYesNo(ctx, fmt.Sprintf("%d개 변경을 %s에 적용하시겠습니까? (y/n): ", len(pending), dir))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("적용이 취소되었습니다.")
+			return nil
+		}
+	}
+
+	want := map[string]string{}
+	for _, change := range pending {
+		if change.Action != bundle.ActionDeleted {
+			want[change.Path] = change.SHA256
+		}
+	}
+	if written, err := bundle.Extract(ctx, archive, dir, want); err != nil {
+		if len(written) > 0 {
+			fmt.Printf("⚠️  적용이 끝나지 않았습니다. 이미 적용한 파일 %d개:\n", len(written))
+			for _, rel := range written {
+				fmt.Printf("   %s\n", rel)
+			}
+		}
+		if interrupted(err) {
+			fmt.Println("⏹️  번들 적용이 중단되었습니다. 다시 실행하면 이미 적용한 파일은 건너뜁니다.")
+		}
+		return fmt.Errorf("번들 적용 실패: %w", err)
+	}
+	for _, change := range pending {
+		if change.Action != bundle.ActionDeleted {
+			continue
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("번들 적용 중단: %w", ctx.Err())
+		}
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(change.Path))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("파일 삭제 실패: %s: %w", change.Path, err)
+		}
+	}
+
+	fmt.Printf("✅ %d개 변경을 적용했습니다.\n", len(pending))
+	return nil
+}
+
+// localChanges 기준 매니페스트와 현재 대상을 비교 (필터로 제외되어 동기화하지 않는 파일은 무시)
+func localChanges(engine *sync.SyncEngine, profile *config.SyncProfile, base *manifest.Manifest) ([]bundle.Change, error) {
+	files, err := manifest.Files(profile.LocalPath, engine.ArtifactDirs(profile))
+	if err != nil {
+		return nil, err
+	}
+	filters := sync.NewFilterSet(engine.FilterRules(profile), profile.LocalPath)
+	index := base.Index()
+	changes := []bundle.Change{}
+
+	current := make(map[string]bool, len(files))
+	for _, rel := range files {
+		current[rel] = true
+		if !filters.Decide(rel, false).Included {
+			continue
+		}
+
+		path := filepath.Join(profile.LocalPath, filepath.FromSlash(rel))
+		entry, known := index[rel]
+		if known && manifest.Matches(path, entry) {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("파일 정보 확인 실패: %w", err)
+		}
+		sum, err := manifest.HashFile(path)
+		if err != nil {
+			return nil, err
+		}
+		change := bundle.Change{Path: rel, Action: bundle.ActionAdded, Size: info.Size(), ModTime: info.ModTime(), SHA256: sum}
+		if known {
+			change.Action = bundle.ActionModified
+			change.BaseSHA256 = entry.SHA256
+		}
+		changes = append(changes, change)
+	}
+
+	for _, entry := range base.Entries {
+		if !current[entry.Path] && filters.Decide(entry.Path, false).Included {
+			changes = append(changes, bundle.Change{Path: entry.Path, Action: bundle.ActionDeleted, BaseSHA256: entry.SHA256})
+		}
+	}
+	return changes, nil
+}
+
+// bundleStatus 서버 트리의 현재 파일과 번들 변경 비교
+// 적용할 변경이면 "", 이미 적용되었거나 충돌이면 그 이유 (conflict는 충돌 여부)
+func bundleStatus(dir string, change bundle.Change) (string, bool) {
+	sum, err := manifest.HashFile(filepath.Join(dir, filepath.FromSlash(change.Path)))
+	exists := err == nil
+
+	switch change.Action {
+	case bundle.ActionAdded:
+		if !exists {
+			return "", false
+		}
+		if sum == change.SHA256 {
+			return "이미 같은 내용", false
+		}
+		return "서버에 다른 내용의 파일이 있음", true
+	case bundle.ActionModified:
+		if !exists {
+			return "서버에서 삭제됨", true
+		}
+		if sum == change.SHA256 {
+			return "이미 같은 내용", false
+		}
+		if sum != change.BaseSHA256 {
+			return "동기화 이후 서버에서도 수정됨", true
+		}
+	case bundle.ActionDeleted:
+		if !exists {
+			return "이미 삭제됨", false
+		}
+		if sum != change.BaseSHA256 {
+			return "동기화 이후 서버에서 수정됨", true
+		}
+	}
+	return "", false
+}
+
+// showBundleChanges 번들 변경 목록 표시
+func showBundleChanges(changes []bundle.Change) {
+	for _, change := range changes {
+		size := ""
+		if change.Action != bundle.ActionDeleted {
+			size = "  " + config.FormatSize(change.Size)
+		}
+		fmt.Printf("%s %s%s\n", bundleIcon(change.Action), change.Path, size)
+	}
+}
+
+// bundleIcon 변경 종류에 따른 아이콘
+func bundleIcon(action string) string {
+	switch action {
+	case bundle.ActionAdded:
+		return "📄"
+	case bundle.ActionModified:
+		return "📝"
+	default:
+		return "🗑️ "
+	}
+}
+
+// recordSynced 동기화 직후의 대상 상태를 collect의 비교 기준으로 기록
+// 기록이 있으면 이번에 복사/삭제한 경로만 다시 해시하고, 처음이면 성공한 동기화 뒤에 전체를 해시
+func recordSynced(cfg *config.Config, engine *sync.SyncEngine, profile *config.SyncProfile, changes *sync.SyncResult, cause error, out io.Writer) {
+	m, err := state.LoadSynced(cfg.Sync.StateDir, profile.ID, profile.LocalPath)
+	if err != nil {
+		logger.Warnf("동기화 매니페스트 읽기 실패: %v", err)
+		return
+	}
+
+	if m == nil {
+		if cause != nil {
+			return
+		}
+		fmt.Fprintf(out, "🔍 로컬 변경 추적을 위해 파일 해시 계산 중: %s\n", profile.LocalPath)
+		if m, err = manifest.Build(profile.LocalPath, engine.ArtifactDirs(profile)); err != nil {
+			logger.Warnf("동기화 매니페스트 생성 실패: %v", err)
+			return
+		}
+		m.Profile = profile.ID
+	} else {
+		paths := make([]string, 0, len(changes.Changes)+len(changes.Deletions))
+		for _, change := range changes.Changes {
+			paths = append(paths, change.Path)
+		}
+		paths = append(paths, changes.Deletions...)
+		if err := m.Update(profile.LocalPath, paths); err != nil {
+			logger.Warnf("동기화 매니페스트 갱신 실패: %v", err)
+			return
+		}
+	}
+
+	if err := state.SaveSynced(cfg.Sync.StateDir, m); err != nil {
+		logger.Warnf("동기화 매니페스트 저장 실패: %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	gosync "sync"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/schedule"
+	"sync-tool/internal/state"
+	"sync-tool/internal/sync"
+)
+
+// DaemonOptions daemon 실행 옵션
+type DaemonOptions struct {
+	Tick    time.Duration // 예약 확인 주기
+	NoCache bool
+}
+
+// retryDelay 실패한 예약 실행을 다시 시도하기까지 기다리는 시간 (다음 cron 시각이 더 이르면 그때 실행)
+const retryDelay = 15 * time.Minute
+
+// scheduledJob schedule이 설정된 프로필
+type scheduledJob struct {
+	profile *config.SyncProfile
+	cron    *schedule.Cron
+	windows []schedule.Window
+	night   schedule.Window
+}
+
+// scheduler 예약된 작업 실행과 상태 관리
+type scheduler struct {
+	cfg  *config.Config
+	opts DaemonOptions
+	jobs []*scheduledJob
+
+	mu       gosync.Mutex
+	states   map[string]*state.ScheduleState
+	running  map[string]bool // 실행 중인 프로필
+	targets  map[string]bool // 사용 중인 대상 경로
+	wg       gosync.WaitGroup
+	outputMu gosync.Mutex
+}
+
+// Daemon schedule이 설정된 프로필을 예약 시각과 허용 시간대에 맞춰 계속 실행
+func Daemon(cfg *config.Config, opts DaemonOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	jobs, err := scheduledJobs(cfg)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return fmt.Errorf("schedule이 설정된 프로필이 없습니다")
+	}
+
+	states, err := state.LoadSchedule(cfg.Sync.StateDir)
+	if err != nil {
+		return err
+	}
+
+	if opts.Tick <= 0 {
+		opts.Tick = 30 * time.Second
+	}
+
+	s := &scheduler{
+		cfg:     cfg,
+		opts:    opts,
+		jobs:    jobs,
+		states:  states,
+		running: make(map[string]bool),
+		targets: make(map[string]bool),
+	}
+
+	// 저장된 다음 실행 시각이 지났으면 중단된 동안 놓친 실행이므로 바로 실행
+	now := time.Now()
+	fmt.Println("=== 예약된 프로필 ===")
+	for _, job := range jobs {
+		st, exists := states[job.profile.ID]
+		if !exists {
+			st = &state.ScheduleState{}
+			states[job.profile.ID] = st
+		}
+		if st.NextRun.IsZero() || st.Cron != job.cron.String() {
+			st.NextRun = job.cron.Next(now)
+			st.Cron = job.cron.String()
+		}
+		fmt.Printf("• %s: %s, 다음 실행: %s\n", job.profile.ID, job.cron, st.NextRun.Format("2006-01-02 15:04"))
+	}
+	s.save()
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	fmt.Printf("daemon 실행 중... (확인 주기: %v, 종료: Ctrl-C)\n", opts.Tick)
+
+	ticker := time.NewTicker(opts.Tick)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("새 작업 예약을 중단하고 실행 중인 작업이 정리되기를 기다립니다...")
+			s.wg.Wait()
+			s.save()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// scheduledJobs schedule이 설정된 프로필을 파싱
+func scheduledJobs(cfg *config.Config) ([]*scheduledJob, error) {
+	jobs := []*scheduledJob{}
+	for _, id := range cfg.ProfileIDs() {
+		profile, _ := findProfile(cfg, id)
+		sched := profile.Schedule
+		if sched.Cron == "" {
+			continue
+		}
+
+		cron, err := schedule.ParseCron(sched.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("%s 프로필 schedule 오류: %w", id, err)
+		}
+		windows, err := schedule.ParseWindows(sched.Windows)
+		if err != nil {
+			return nil, fmt.Errorf("%s 프로필 schedule 오류: %w", id, err)
+		}
+		nightHours := sched.BWLimit.NightHours
+		if nightHours == "" {
+			nightHours = config.DefaultNightHours
+		}
+		night, err := schedule.ParseWindow(nightHours)
+		if err != nil {
+			return nil, fmt.Errorf("%s 프로필 bwlimit 오류: %w", id, err)
+		}
+
+		jobs = append(jobs, &scheduledJob{profile: profile, cron: cron, windows: windows, night: night})
+	}
+	return jobs, nil
+}
+
+// tick 실행 시각이 된 작업 중 허용 시간대이고 대상이 비어 있는 작업 시작
+func (s *scheduler) tick(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, job := range s.jobs {
+		id := job.profile.ID
+		st := s.states[id]
+		if s.running[id] || st.NextRun.IsZero() || now.Before(st.NextRun) {
+			continue
+		}
+
+		// 허용 시간대가 아니면 실행 예정 상태로 유지했다가 시간대가 열리면 실행
+		if !schedule.InAny(job.windows, now) {
+			logger.Debugf("%s: 허용 시간대가 아니어서 대기 중", id)
+			continue
+		}
+
+		profile := *job.profile
+		if err := resolveTarget(&profile); err != nil {
+			logger.Warnf("예약 실행 건너뜀: %v", err)
+			s.finish(job, now, err)
+			continue
+		}
+
+		target := filepath.Clean(profile.LocalPath)
+		if s.targets[target] {
+			logger.Debugf("%s: 같은 대상(%s)에서 다른 작업이 실행 중이어서 대기", id, target)
+			continue
+		}
+
+		s.running[id] = true
+		s.targets[target] = true
+		s.wg.Add(1)
+		go s.run(ctx, job, &profile, target)
+	}
+}
+
+// run 작업 하나를 계획하고 확인 없이 적용
+func (s *scheduler) run(ctx context.Context, job *scheduledJob, profile *config.SyncProfile, target string) {
+	defer s.wg.Done()
+
+	start := time.Now()
+	writer := newPrefixWriter(os.Stdout, &s.outputMu, fmt.Sprintf("[%s] ", profile.ID))
+
+	engine := sync.NewSyncEngine(s.cfg)
+	engine.SetUseCache(!s.opts.NoCache)
+	engine.SetOutput(writer)
+	bwlimit := job.bandwidthLimit(start)
+	engine.SetBandwidthLimit(bwlimit)
+
+	err := engine.ValidateProfile(profile)
+	var changes *sync.SyncResult
+	if err == nil {
+		changes, err = planProfile(ctx, s.cfg, engine, profile, writer)
+	}
+	if err == nil && (changes.HasChanges || changes.HasDeletions) {
+		err = applyPlan(ctx, s.cfg, engine, profile, changes, writer)
+	}
+	writer.Flush()
+
+	fields := logger.Fields{
+		"profile":  profile.ID,
+		"target":   target,
+		"bwlimit":  bwlimit,
+		"duration": time.Since(start).Round(time.Second).String(),
+	}
+	if changes != nil {
+		fields["changes"] = len(changes.Changes)
+		fields["deletions"] = len(changes.Deletions)
+	}
+	if err != nil {
+		logger.WithFields(fields).Errorf("예약 실행 실패: %v", err)
+	} else {
+		logger.WithFields(fields).Info("예약 실행 완료")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, profile.ID)
+	delete(s.targets, target)
+	s.finish(job, start, err)
+}
+
+// finish 실행 결과와 다음 실행 시각 저장 (s.mu를 잡은 상태에서 호출)
+// 중단된 실행은 다음 실행 시각을 그대로 두어 daemon을 다시 시작하면 바로 이어서 실행하고,
+// 실패한 실행은 다음 cron 시각까지 미루지 않고 retryDelay 뒤에 다시 시도
+func (s *scheduler) finish(job *scheduledJob, start time.Time, err error) {
+	st := s.states[job.profile.ID]
+	st.LastRun = start
+	st.LastResult = state.ResultSuccess
+	st.LastError = ""
+	now := time.Now()
+	next := job.cron.Next(now)
+	if err != nil {
+		st.LastResult = state.ResultFailure
+		if retry := now.Add(retryDelay); retry.Before(next) {
+			next = retry
+		}
+		if interrupted(err) {
+			st.LastResult = state.ResultInterrupted
+			next = st.NextRun
+		}
+		st.LastError = err.Error()
+	}
+	st.NextRun = next
+	st.Cron = job.cron.String()
+	logger.Infof("%s 다음 실행: %s", job.profile.ID, st.NextRun.Format("2006-01-02 15:04"))
+
+	if err := state.SaveSchedule(s.cfg.Sync.StateDir, s.states); err != nil {
+		logger.Warnf("예약 상태 저장 실패: %v", err)
+	}
+}
+
+// save 현재 예약 상태 저장
+func (s *scheduler) save() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := state.SaveSchedule(s.cfg.Sync.StateDir, s.states); err != nil {
+		logger.Warnf("예약 상태 저장 실패: %v", err)
+	}
+}
+
+// bandwidthLimit 실행 시각에 맞는 주간/야간 대역폭 제한
+func (j *scheduledJob) bandwidthLimit(t time.Time) string {
+	if j.night.Contains(t) {
+		return j.profile.Schedule.BWLimit.Night
+	}
+	return j.profile.Schedule.BWLimit.Day
+}
@@ -0,0 +1,247 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/device"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/state"
+	"sync-tool/internal/sync"
+)
+
+// DevicesOptions devices 명령 옵션
+type DevicesOptions struct {
+	Known      bool   // 연결 여부와 관계없이 기록된 모든 장치 표시
+	StaleAfter string // 마지막 동기화 후 이 기간이 지나면 경고 (예: 30d)
+}
+
+// Devices 연결된 USB 장치와 프로필 매칭, 마지막 동기화 기록 표시
+func Devices(cfg *config.Config, opts DevicesOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	staleAfter, err := config.ParseDuration(opts.StaleAfter)
+	if err != nil {
+		return err
+	}
+
+	if opts.Known {
+		return showKnownDevices(cfg, staleAfter)
+	}
+
+	volumes, err := device.Volumes()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("=== 연결된 장치 ===")
+	shown := 0
+	for _, volume := range volumes {
+		profiles := matchingProfiles(cfg, volume)
+		if !volume.Removable && len(profiles) == 0 {
+			continue
+		}
+		shown++
+
+		var record *state.DeviceRecord
+		if id := volume.ID(); id != "" && len(profiles) > 0 {
+			err := state.UpdateDevice(cfg.Sync.StateDir, id, func(r *state.DeviceRecord) {
+				updateDeviceRecord(r, volume)
+				copied := *r
+				record = &copied
+			})
+			if err != nil {
+				logger.Warnf("장치 기록 갱신 실패: %v", err)
+			}
+		}
+
+		showVolume(volume, profiles, record, staleAfter)
+	}
+
+	if shown == 0 {
+		fmt.Println("연결된 이동식 장치가 없습니다.")
+	}
+	return nil
+}
+
+// showVolume 장치 하나의 정보 표시
+func showVolume(volume device.Volume, profiles []string, record *state.DeviceRecord, staleAfter time.Duration) {
+	label := volume.Label
+	if label == "" {
+		label = "(라벨 없음)"
+	}
+	fmt.Printf("• %s (%s)\n", label, volume.Point)
+	fmt.Printf("  장치: %s, 파일시스템: %s\n", volume.Device, volume.FSType)
+	fmt.Printf("  UUID: %s, 시리얼: %s\n", orDash(volume.UUID), orDash(volume.Serial))
+
+	if total, free, err := device.Usage(volume.Point); err == nil {
+		fmt.Printf("  용량: %s (여유 %s)\n", config.FormatSize(int64(total)), config.FormatSize(int64(free)))
+	}
+
+	if len(profiles) > 0 {
+		fmt.Printf("  프로필: %s\n", strings.Join(profiles, ", "))
+	} else {
+		fmt.Println("  프로필: 일치하는 프로필 없음")
+	}
+
+	fmt.Printf("  마지막 동기화: %s\n", lastSyncText(record, staleAfter))
+	fmt.Println()
+}
+
+// showKnownDevices 기록된 모든 장치를 마지막 동기화가 오래된 순으로 표시
+func showKnownDevices(cfg *config.Config, staleAfter time.Duration) error {
+	records, err := state.LoadDevices(cfg.Sync.StateDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("=== 기록된 장치 ===")
+	if len(records) == 0 {
+		fmt.Println("기록된 장치가 없습니다.")
+		return nil
+	}
+
+	list := make([]*state.DeviceRecord, 0, len(records))
+	for _, record := range records {
+		list = append(list, record)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LastSync.Before(list[j].LastSync)
+	})
+
+	for _, record := range list {
+		fmt.Printf("• %s (%s)\n", orDash(record.Label), record.ID)
+		fmt.Printf("  마지막 연결: %s, 동기화 횟수: %d\n", record.LastSeen.Format("2006-01-02 15:04:05"), record.Syncs)
+		fmt.Printf("  마지막 동기화: %s\n", lastSyncText(record, staleAfter))
+		fmt.Println()
+	}
+	return nil
+}
+
+// matchingProfiles 볼륨에 해당하는 프로필 ID 목록 (장치 조건 또는 로컬 경로 기준)
+func matchingProfiles(cfg *config.Config, volume device.Volume) []string {
+	matched := []string{}
+	for _, id := range cfg.ProfileIDs() {
+		profile := cfg.Profiles[id]
+		if profile.Device.IsSet() {
+			if device.Matches(volume, profile.Device.Label, profile.Device.UUID) {
+				matched = append(matched, id)
+			}
+			continue
+		}
+		if _, ok := subPath(volume.Point, profile.LocalPath); ok && volume.Point != "/" {
+			matched = append(matched, id)
+		}
+	}
+	return matched
+}
+
+// recordSync 동기화 결과를 실행 기록과 장치별 기록에 저장 (실패는 경고만 기록)
+func recordSync(cfg *config.Config, profile *config.SyncProfile, changes *sync.SyncResult, start time.Time, cause error) {
+	entry, volume := historyEntry(profile, changes, start, cause)
+
+	if err := state.AppendHistory(cfg.Sync.StateDir, entry); err != nil {
+		logger.Warnf("동기화 기록 저장 실패: %v", err)
+	}
+
+	if entry.DeviceID == "" {
+		return
+	}
+	err := state.UpdateDevice(cfg.Sync.StateDir, entry.DeviceID, func(record *state.DeviceRecord) {
+		updateDeviceRecord(record, *volume)
+		record.LastSync = entry.Time
+		record.LastProfile = entry.Profile
+		record.LastResult = entry.Result
+		record.Syncs++
+		if record.Profiles == nil {
+			record.Profiles = make(map[string]time.Time)
+		}
+		record.Profiles[entry.Profile] = entry.Time
+	})
+	if err != nil {
+		logger.Warnf("장치 기록 저장 실패: %v", err)
+	}
+}
+
+// recordCheckout 일부 경로만 받은 checkout 결과를 실행 기록에만 저장
+// 대상 전체가 동기화된 것이 아니므로 장치와 프로필의 마지막 동기화 시각은 갱신하지 않음
+func recordCheckout(cfg *config.Config, profile *config.SyncProfile, changes *sync.SyncResult, start time.Time, cause error) {
+	entry, _ := historyEntry(profile, changes, start, cause)
+	entry.Kind = state.KindCheckout
+	if err := state.AppendHistory(cfg.Sync.StateDir, entry); err != nil {
+		logger.Warnf("동기화 기록 저장 실패: %v", err)
+	}
+}
+
+// historyEntry 실행 결과로 기록 항목 구성 (이동식 장치나 장치 프로필이면 볼륨 정보 포함)
+func historyEntry(profile *config.SyncProfile, changes *sync.SyncResult, start time.Time, cause error) (state.HistoryEntry, *device.Volume) {
+	entry := state.HistoryEntry{
+		Time:     start,
+		Profile:  profile.ID,
+		Target:   profile.LocalPath,
+		Result:   state.ResultSuccess,
+		Duration: time.Since(start),
+	}
+	if changes != nil {
+		entry.Changes = len(changes.Changes)
+		entry.Deletions = len(changes.Deletions)
+		entry.Bytes = changes.TotalBytes
+		entry.Attempts = changes.Attempts
+		entry.Copied = changes.Copied
+		entry.Removed = changes.Removed
+	}
+	if cause != nil {
+		entry.Result = state.ResultFailure
+		if interrupted(cause) {
+			entry.Result = state.ResultInterrupted
+		}
+		entry.Error = cause.Error()
+	}
+
+	volume, err := device.FindVolume(profile.LocalPath)
+	if err == nil && (volume.Removable || profile.Device.IsSet()) {
+		entry.DeviceID = volume.ID()
+		entry.Label = volume.Label
+	}
+	return entry, volume
+}
+
+// updateDeviceRecord 현재 볼륨 정보로 장치 기록 갱신
+func updateDeviceRecord(record *state.DeviceRecord, volume device.Volume) {
+	record.Label = volume.Label
+	record.UUID = volume.UUID
+	record.Serial = volume.Serial
+	record.FSType = volume.FSType
+	record.LastSeen = time.Now()
+	if total, _, err := device.Usage(volume.Point); err == nil {
+		record.Capacity = total
+	}
+}
+
+// lastSyncText 마지막 동기화 표시 문자열 (오래되었으면 경고)
+func lastSyncText(record *state.DeviceRecord, staleAfter time.Duration) string {
+	if record == nil || record.LastSync.IsZero() {
+		return "기록 없음"
+	}
+
+	age := time.Since(record.LastSync)
+	text := fmt.Sprintf("%s (%s, %s, %d일 전)",
+		record.LastSync.Format("2006-01-02 15:04:05"), record.LastProfile, record.LastResult, int(age.Hours()/24))
+	if staleAfter > 0 && age > staleAfter {
+		text += " ⚠️  오래됨"
+	}
+	return text
+}
+
+// orDash 빈 값이면 "-" 반환
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
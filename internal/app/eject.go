@@ -0,0 +1,79 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/device"
+	"sync-tool/internal/logger"
+)
+
+// Eject 프로필 대상 볼륨을 플러시하고 안전하게 분리
+func Eject(cfg *config.Config, profileName string) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+	if err := resolveTarget(profile); err != nil {
+		return err
+	}
+
+	return ejectTarget(profile, os.Stdout)
+}
+
+// flushTarget 대상 경로의 파일시스템 버퍼를 디스크에 기록
+func flushTarget(path string, out io.Writer) error {
+	fmt.Fprintf(out, "💾 디스크에 기록 중: %s\n", path)
+	if err := device.Flush(path); err != nil {
+		return fmt.Errorf("버퍼 플러시 실패: %w", err)
+	}
+	return nil
+}
+
+// ejectTarget 플러시, 열린 파일 확인, 마운트 해제 순서로 볼륨 분리
+// 이동식 장치이거나 프로필의 device 조건과 일치하는 볼륨만 분리 (고정 디스크 보호)
+func ejectTarget(profile *config.SyncProfile, out io.Writer) error {
+	path := profile.LocalPath
+	volume, err := device.FindVolume(path)
+	if err != nil {
+		return err
+	}
+	mount := &volume.Mount
+	if mount.Point == "/" {
+		return fmt.Errorf("루트 파일시스템은 분리할 수 없습니다: %s", path)
+	}
+	if !volume.Removable && !device.Matches(*volume, profile.Device.Label, profile.Device.UUID) {
+		return fmt.Errorf("이동식 장치가 아니고 프로필의 device 조건과도 일치하지 않아 분리하지 않습니다: %s (%s)", mount.Point, mount.Device)
+	}
+
+	if err := flushTarget(mount.Point, out); err != nil {
+		return err
+	}
+
+	handles, err := device.OpenHandles(mount.Point)
+	if err != nil {
+		return fmt.Errorf("열린 파일 확인 실패: %w", err)
+	}
+	if len(handles) > 0 {
+		fmt.Fprintf(out, "⚠️  %s 아래에 열린 파일이 있습니다:\n", mount.Point)
+		for _, handle := range handles {
+			fmt.Fprintf(out, "   PID %d (%s): %s\n", handle.PID, handle.Command, handle.Path)
+		}
+		return fmt.Errorf("열린 파일 %d개 때문에 분리할 수 없습니다. 해당 프로그램을 종료한 뒤 다시 시도하세요", len(handles))
+	}
+
+	fmt.Fprintf(out, "⏏️  마운트 해제 중: %s (%s)\n", mount.Point, mount.Device)
+	if err := device.Unmount(mount); err != nil {
+		return err
+	}
+
+	logger.Infof("볼륨 분리 완료: %s", mount.Point)
+	fmt.Fprintf(out, "✅ 이제 USB를 안전하게 뽑아도 됩니다: %s\n", mount.Point)
+	return nil
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/export"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/manifest"
+	"sync-tool/internal/sync"
+)
+
+// ExportOptions export 명령 옵션
+type ExportOptions struct {
+	Format  string // tar, zip, iso
+	Output  string
+	WorkDir string // 파일을 받아 둘 작업 디렉토리의 상위 경로 (비우면 출력 파일이 있는 디렉토리)
+	Label   string // ISO 볼륨 라벨 (비우면 장치 라벨 또는 프로필 ID)
+	NoCache bool
+}
+
+// Export 프로필로 빈 USB에 동기화할 때 받게 될 파일 전체를 매니페스트와 함께 아카이브나 이미지로 저장
+func Export(cfg *config.Config, profileName string, opts ExportOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+	if opts.Output == "" {
+		return fmt.Errorf("출력 파일 경로를 지정하세요 (-o)")
+	}
+	if err := export.Check(opts.Format); err != nil {
+		return err
+	}
+
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	// 시스템 임시 디렉토리는 tmpfs일 수 있으므로 기본으로 출력 파일 옆에 받음
+	workDir := opts.WorkDir
+	if workDir == "" {
+		workDir = filepath.Dir(opts.Output)
+	}
+	work, err := os.MkdirTemp(workDir, ".sync-export-*")
+	if err != nil {
+		return fmt.Errorf("작업 디렉토리 생성 실패: %w", err)
+	}
+	defer os.RemoveAll(work)
+
+	// 빈 작업 디렉토리를 대상으로 삼아 필터가 적용된 전체 파일 집합을 계획하고 받음
+	// (스테이징과 백업은 빈 대상에서 의미가 없으므로 끔)
+	target := *profile
+	target.LocalPath = work
+	target.MountPoint = ""
+	target.Staging = false
+	target.Backup = config.BackupConfig{}
+
+	engine := sync.NewSyncEngine(cfg)
+	engine.SetUseCache(!opts.NoCache)
+	if err := engine.ValidateProfile(&target); err != nil {
+		return fmt.Errorf("프로필 유효성 검사 실패: %w", err)
+	}
+
+	plan, err := engine.DryRun(ctx, &target)
+	if err != nil {
+		return fmt.Errorf("드라이런 실행 실패: %w", err)
+	}
+	if !plan.HasChanges {
+		return fmt.Errorf("내보낼 파일이 없습니다: %s", profile.ServerPath)
+	}
+	fmt.Printf("📦 %s 프로필 내보내기: 항목 %d개", profile.ID, len(plan.Changes))
+	if plan.TotalBytes > 0 {
+		fmt.Printf(", %s", config.FormatSize(plan.TotalBytes))
+	}
+	fmt.Println()
+
+	if err := engine.Sync(ctx, &target, plan); err != nil {
+		if interrupted(err) {
+			return fmt.Errorf("내보내기 중단됨: %w", err)
+		}
+		return fmt.Errorf("파일 받기 실패: %w", err)
+	}
+
+	// 작업 디렉토리에 남은 부분 전송 디렉토리는 이미지에 넣지 않음
+	skip := engine.ArtifactDirs(&target)
+	for _, name := range skip {
+		os.RemoveAll(filepath.Join(work, name))
+	}
+
+	fmt.Println("🔍 매니페스트 생성 중...")
+	m, err := manifest.Build(work, skip)
+	if err != nil {
+		return err
+	}
+	m.Profile = profile.ID
+	m.Target = profile.ServerPath
+
+	label := opts.Label
+	if label == "" {
+		label = profile.Device.Label
+	}
+	if label == "" {
+		label = profile.ID
+	}
+
+	fmt.Printf("💾 %s 형식으로 저장 중: %s\n", opts.Format, opts.Output)
+	if err := export.Write(opts.Format, opts.Output, work, m, label); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ 내보내기가 완료되었습니다: %s (파일 %d개, %s, 매니페스트 %s 포함)\n",
+		opts.Output, len(m.Entries), config.FormatSize(m.TotalSize()), export.ManifestFile)
+	return nil
+}
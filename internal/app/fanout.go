@@ -0,0 +1,407 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/sync"
+)
+
+// defaultJobs 동시 작업 수 기본값
+const defaultJobs = 4
+
+// syncJob 하나의 대상에 대한 동기화 작업
+type syncJob struct {
+	Label    string
+	Profile  *config.SyncProfile
+	Changes  *sync.SyncResult
+	Err      error
+	Duration time.Duration
+}
+
+// syncTargets 하나의 프로필을 여러 대상 장치에 동시에 동기화
+func syncTargets(ctx context.Context, cfg *config.Config, profile *config.SyncProfile, opts SyncOptions) error {
+	targets, err := expandTargets(opts.Targets)
+	if err != nil {
+		return err
+	}
+
+	jobs := make([]*syncJob, 0, len(targets))
+	for _, target := range targets {
+		targetProfile := *profile
+		if profile.Device.IsSet() {
+			// 장치 프로필은 대상을 볼륨 루트로 보고 local_path를 그 아래에 적용
+			bindTarget(&targetProfile, target)
+		} else {
+			targetProfile.LocalPath = target
+		}
+		jobs = append(jobs, &syncJob{Label: target, Profile: &targetProfile})
+	}
+
+	fmt.Printf("대상 장치: %d개 (동시 작업: %d)\n", len(jobs), jobLimit(opts.Jobs, len(jobs)))
+	return runJobs(ctx, cfg, jobs, opts)
+}
+
+// runJobs 모든 작업을 계획하고, 한 번 확인받은 뒤 제한된 워커 풀로 적용
+func runJobs(ctx context.Context, cfg *config.Config, jobs []*syncJob, opts SyncOptions) error {
+	workers := jobLimit(opts.Jobs, len(jobs))
+
+	// 1단계: 대상별 계획 (드라이런)
+	logger.Info("대상별 변경사항 확인 중...")
+	forEachJob(ctx, jobs, workers, func(job *syncJob) {
+		engine := sync.NewSyncEngine(cfg)
+		engine.SetUseCache(!opts.NoCache)
+		engine.SetBandwidthLimit(opts.BWLimit)
+
+		if err := resolveTarget(job.Profile); err != nil {
+			job.Err = err
+			return
+		}
+		if err := engine.ValidateProfile(job.Profile); err != nil {
+			job.Err = fmt.Errorf("프로필 유효성 검사 실패: %w", err)
+			return
+		}
+
+		changes, err := planProfile(ctx, cfg, engine, job.Profile, os.Stdout)
+		if err != nil {
+			job.Err = err
+			return
+		}
+		job.Changes = changes
+	})
+
+	showPlanSummary(jobs)
+
+	if ctx.Err() != nil {
+		fmt.Println("⏹️  계획 중 중단되어 동기화하지 않았습니다.")
+		return jobsError(jobs)
+	}
+
+	pending := pendingJobs(jobs)
+	if len(pending) == 0 {
+		fmt.Println("✅ 동기화할 변경사항이 없습니다.")
+		return jobsError(jobs)
+	}
+
+	if opts.DryRun {
+		fmt.Println("드라이런 모드로 실행되었습니다. 실제 동기화는 수행되지 않았습니다.")
+		return jobsError(jobs)
+	}
+
+	// 2단계: 한 번만 확인
+	if !opts.AutoConfirm && cfg.UI.ConfirmActions {
+		confirmed, err := askYesNo(ctx, fmt.Sprintf("%d개 대상에 동기화하시겠습니까? (y/n): ", len(pending)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("동기화가 취소되었습니다.")
+			return nil
+		}
+	}
+
+	// 3단계: 워커 풀로 적용 (같은 대상 경로의 작업은 순서대로 실행)
+	// 앞선 작업이 같은 대상을 바꾼 뒤에는 처음 계획이 맞지 않으므로 적용 직전에 다시 계획
+	replan := make(map[*syncJob]bool)
+	seen := make(map[string]bool)
+	for _, job := range pending {
+		target := filepath.Clean(job.Profile.LocalPath)
+		replan[job] = seen[target]
+		seen[target] = true
+	}
+
+	logger.Info("동기화 실행 중...")
+	var outputMu gosync.Mutex
+	forEachTarget(ctx, pending, workers, func(job *syncJob) {
+		engine := sync.NewSyncEngine(cfg)
+		engine.SetUseCache(!opts.NoCache)
+		engine.SetBandwidthLimit(opts.BWLimit)
+		writer := newPrefixWriter(os.Stdout, &outputMu, fmt.Sprintf("[%s] ", job.Label))
+		engine.SetOutput(writer)
+		defer writer.Flush()
+
+		start := time.Now()
+		if replan[job] {
+			changes, err := planProfile(ctx, cfg, engine, job.Profile, writer)
+			if err != nil {
+				job.Err = err
+				return
+			}
+			fmt.Fprintf(writer, "🔁 같은 대상의 앞선 동기화 후 다시 계획: 복사 %d개, 삭제 %d개\n",
+				len(changes.Changes), len(changes.Deletions))
+			job.Changes = changes
+			if !changes.HasChanges && !changes.HasDeletions {
+				return
+			}
+		}
+		if err := applyPlan(ctx, cfg, engine, job.Profile, job.Changes, writer); err != nil {
+			job.Err = err
+		}
+		job.Duration = time.Since(start)
+	})
+
+	showJobResults(jobs)
+	return jobsError(jobs)
+}
+
+// syncProfiles 여러 프로필(전체 또는 그룹)을 한 번에 계획하고 적용
+func syncProfiles(ctx context.Context, cfg *config.Config, opts SyncOptions) error {
+	ids := cfg.ProfileIDs()
+	if opts.Group != "" {
+		members, err := cfg.GroupProfiles(opts.Group)
+		if err != nil {
+			return err
+		}
+		ids = members
+	}
+
+	if len(ids) == 0 {
+		return fmt.Errorf("동기화할 프로필이 없습니다")
+	}
+
+	jobs := make([]*syncJob, 0, len(ids))
+	for _, id := range ids {
+		profile, err := findProfile(cfg, id)
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, &syncJob{Label: id, Profile: profile})
+	}
+
+	fmt.Printf("동기화할 프로필: %s (동시 작업: %d)\n", strings.Join(ids, ", "), jobLimit(opts.Jobs, len(jobs)))
+	return runJobs(ctx, cfg, jobs, opts)
+}
+
+// forEachJob 최대 workers개의 고루틴으로 작업 실행 (ctx가 취소되면 남은 작업은 시작하지 않음)
+func forEachJob(ctx context.Context, jobs []*syncJob, workers int, fn func(job *syncJob)) {
+	queue := make(chan *syncJob)
+	var wg gosync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				if ctx.Err() != nil {
+					job.Err = fmt.Errorf("시작 전 중단됨: %w", ctx.Err())
+					continue
+				}
+				fn(job)
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// forEachTarget 대상 경로별로 작업을 묶어, 같은 대상의 작업은 순차 실행하고 대상끼리는 병렬 실행
+func forEachTarget(ctx context.Context, jobs []*syncJob, workers int, fn func(job *syncJob)) {
+	chains := [][]*syncJob{}
+	index := make(map[string]int)
+	for _, job := range jobs {
+		target := filepath.Clean(job.Profile.LocalPath)
+		i, exists := index[target]
+		if !exists {
+			i = len(chains)
+			index[target] = i
+			chains = append(chains, nil)
+		}
+		chains[i] = append(chains[i], job)
+	}
+
+	heads := make([]*syncJob, len(chains))
+	for i, chain := range chains {
+		heads[i] = chain[0]
+	}
+
+	forEachJob(ctx, heads, jobLimit(workers, len(chains)), func(head *syncJob) {
+		for _, job := range chains[index[filepath.Clean(head.Profile.LocalPath)]] {
+			if ctx.Err() != nil {
+				job.Err = fmt.Errorf("시작 전 중단됨: %w", ctx.Err())
+				continue
+			}
+			fn(job)
+		}
+	})
+}
+
+// pendingJobs 오류 없이 변경사항이 있는 작업만 반환
+func pendingJobs(jobs []*syncJob) []*syncJob {
+	pending := []*syncJob{}
+	for _, job := range jobs {
+		if job.Err == nil && job.Changes != nil && (job.Changes.HasChanges || job.Changes.HasDeletions) {
+			pending = append(pending, job)
+		}
+	}
+	return pending
+}
+
+// showPlanSummary 대상별 계획 요약 표시
+func showPlanSummary(jobs []*syncJob) {
+	fmt.Println()
+	fmt.Println("=== 대상별 변경사항 요약 ===")
+	for _, job := range jobs {
+		switch {
+		case job.Err != nil:
+			fmt.Printf("❌ %s: %v\n", job.Label, job.Err)
+		case !job.Changes.HasChanges && !job.Changes.HasDeletions:
+			fmt.Printf("✅ %s: 변경사항 없음\n", job.Label)
+		default:
+			fmt.Printf("• %s: 복사 %d개, 삭제 %d개\n",
+				job.Label, len(job.Changes.Changes), len(job.Changes.Deletions))
+		}
+	}
+	fmt.Println()
+}
+
+// showJobResults 대상별 최종 성공/실패 요약 표시
+func showJobResults(jobs []*syncJob) {
+	fmt.Println()
+	fmt.Println("=== 대상별 동기화 결과 ===")
+	for _, job := range jobs {
+		if interrupted(job.Err) {
+			fmt.Printf("⏹️  %s: 중단 - %v\n", job.Label, job.Err)
+			continue
+		}
+		if job.Err != nil {
+			fmt.Printf("❌ %s: 실패 - %v\n", job.Label, job.Err)
+			continue
+		}
+		if job.Changes == nil || (!job.Changes.HasChanges && !job.Changes.HasDeletions) {
+			fmt.Printf("✅ %s: 변경사항 없음\n", job.Label)
+			continue
+		}
+		fmt.Printf("✅ %s: 성공 (복사 %d개, 삭제 %d개, %v)\n",
+			job.Label, len(job.Changes.Changes), len(job.Changes.Deletions), job.Duration.Round(time.Second))
+	}
+}
+
+// jobsError 실패한 작업이 있으면 오류 반환
+func jobsError(jobs []*syncJob) error {
+	failed := []string{}
+	for _, job := range jobs {
+		if job.Err != nil {
+			failed = append(failed, job.Label)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d개 대상 실패: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// jobLimit 동시 작업 수 결정
+func jobLimit(jobs int, total int) int {
+	if jobs <= 0 {
+		jobs = defaultJobs
+	}
+	if jobs > total {
+		jobs = total
+	}
+	if jobs < 1 {
+		jobs = 1
+	}
+	return jobs
+}
+
+// expandTargets 대상 목록의 글롭 패턴과 label:/uuid: 조건을 실제 마운트 경로로 확장
+func expandTargets(patterns []string) ([]string, error) {
+	targets := []string{}
+	seen := make(map[string]bool)
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		matches := []string{pattern}
+		if points, ok, err := expandVolumeTarget(pattern); ok {
+			if err != nil {
+				return nil, err
+			}
+			matches = points
+		} else if strings.ContainsAny(pattern, "*?[") {
+			globbed, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("잘못된 대상 패턴: %s", pattern)
+			}
+			if len(globbed) == 0 {
+				return nil, fmt.Errorf("패턴과 일치하는 대상이 없습니다: %s", pattern)
+			}
+			matches = globbed
+		}
+
+		for _, match := range matches {
+			match = filepath.Clean(match)
+			if !seen[match] {
+				seen[match] = true
+				targets = append(targets, match)
+			}
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("동기화 대상이 지정되지 않았습니다")
+	}
+	return targets, nil
+}
+
+// prefixWriter 줄 단위로 접두사를 붙여 공유 출력에 기록 (동시 출력이 섞이지 않도록)
+type prefixWriter struct {
+	out    io.Writer
+	mu     *gosync.Mutex
+	prefix string
+	buf    bytes.Buffer
+}
+
+// newPrefixWriter 새로운 접두사 출력기 생성
+func newPrefixWriter(out io.Writer, mu *gosync.Mutex, prefix string) *prefixWriter {
+	return &prefixWriter{out: out, mu: mu, prefix: prefix}
+}
+
+// Write 완성된 줄만 접두사를 붙여 출력
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadBytes('\n')
+		if err != nil {
+			// 개행이 없는 나머지는 다음 쓰기까지 보관
+			w.buf.Write(line)
+			break
+		}
+		// 진행률 표시의 \r 갱신은 마지막 상태만 출력
+		if idx := bytes.LastIndexByte(bytes.TrimRight(line, "\n"), '\r'); idx >= 0 {
+			line = line[idx+1:]
+		}
+		fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+	}
+	return len(p), nil
+}
+
+// Flush 남은 출력 기록
+func (w *prefixWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf.Len() > 0 {
+		fmt.Fprintf(w.out, "%s%s\n", w.prefix, w.buf.String())
+		w.buf.Reset()
+	}
+}
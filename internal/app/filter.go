@@ -0,0 +1,140 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/sync"
+)
+
+// FilterTestOptions 필터 테스트 옵션
+type FilterTestOptions struct {
+	Walk         bool
+	Root         string
+	ShowExcluded bool
+}
+
+// FilterTest 경로별로 어떤 필터 규칙이 일치했는지와 최종 판정 표시
+func FilterTest(cfg *config.Config, profileName string, paths []string, opts FilterTestOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+
+	if err := resolveTarget(profile); err != nil {
+		logger.Debugf("대상 장치 확인 실패, 로컬 트리 사용 안 함: %v", err)
+	}
+
+	engine := sync.NewSyncEngine(cfg)
+	rules := engine.FilterRules(profile)
+
+	root := opts.Root
+	if root == "" {
+		if cacheDir, ok := engine.CacheDir(profile); ok {
+			root = cacheDir
+		} else if _, err := os.Stat(profile.LocalPath); err == nil {
+			root = profile.LocalPath
+		}
+	}
+
+	fmt.Printf("=== 필터 테스트: %s ===\n", profile.ID)
+	fmt.Println("적용 규칙 (위에서부터 먼저 일치하는 규칙 적용):")
+	for _, rule := range rules {
+		fmt.Printf("  %-24s %s\n", rule.Source, rule.String())
+	}
+	if root != "" {
+		fmt.Printf("기준 트리: %s\n", root)
+	}
+	fmt.Println()
+
+	set := sync.NewFilterSet(rules, root)
+
+	if !opts.Walk {
+		if len(paths) == 0 {
+			return fmt.Errorf("테스트할 경로를 지정하거나 --walk를 사용하세요")
+		}
+		for _, p := range paths {
+			isDir := strings.HasSuffix(p, "/")
+			if !isDir && root != "" {
+				if info, err := os.Stat(filepath.Join(root, p)); err == nil {
+					isDir = info.IsDir()
+				}
+			}
+			printDecision(set.Decide(p, isDir))
+		}
+		return nil
+	}
+
+	if root == "" {
+		return fmt.Errorf("순회할 트리가 없습니다. --root로 디렉토리를 지정하세요")
+	}
+
+	// 트리 순회: 전송될 파일 목록
+	included, excluded := 0, 0
+	err = set.Walk(func(decision sync.FilterDecision) error {
+		if len(paths) > 0 && !underAny(decision.Path, paths) {
+			return nil
+		}
+		if decision.Included {
+			if !decision.IsDir {
+				included++
+				fmt.Printf("📄 %s\n", decision.Path)
+			}
+			return nil
+		}
+		excluded++
+		if opts.ShowExcluded {
+			printDecision(decision)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("트리 순회 실패: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("전송 대상 파일: %d개, 제외된 항목: %d개\n", included, excluded)
+	return nil
+}
+
+// printDecision 판정 결과 한 줄 출력
+func printDecision(decision sync.FilterDecision) {
+	verdict := "✅ 포함"
+	if !decision.Included {
+		verdict = "❌ 제외"
+	}
+
+	display := decision.Path
+	if decision.IsDir {
+		display += "/"
+	}
+
+	switch {
+	case decision.Rule == nil:
+		fmt.Printf("%s  %s  (일치하는 규칙 없음, 기본 포함)\n", verdict, display)
+	case decision.Parent != "":
+		fmt.Printf("%s  %s  ← 상위 디렉토리 %s/ 제외 (%s: %s)\n",
+			verdict, display, decision.Parent, decision.Rule.Source, decision.Rule.String())
+	default:
+		fmt.Printf("%s  %s  ← %s: %s\n", verdict, display, decision.Rule.Source, decision.Rule.String())
+	}
+}
+
+// underAny 경로가 주어진 경로 중 하나와 같거나 그 하위인지 확인
+func underAny(p string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.Trim(filepath.ToSlash(prefix), "/")
+		if p == prefix || strings.HasPrefix(p, prefix+"/") || strings.HasPrefix(prefix, p+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/sync"
+)
+
+// profileOverlap 대상 경로가 겹치는 두 프로필
+type profileOverlap struct {
+	A, B *config.SyncProfile
+}
+
+// Lint 설정 검사 (대상 경로가 겹치는 프로필 탐지)
+func Lint(cfg *config.Config, withPlan bool) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	fmt.Println("=== 설정 검사 ===")
+
+	overlaps := findOverlaps(cfg)
+	if len(overlaps) == 0 {
+		fmt.Println("✅ 대상 경로가 겹치는 프로필이 없습니다.")
+		return nil
+	}
+
+	engine := sync.NewSyncEngine(cfg)
+	conflicts := 0
+
+	for _, overlap := range overlaps {
+		a, b := overlap.A, overlap.B
+		fmt.Printf("⚠️  %s ↔ %s: 로컬 경로가 겹칩니다\n", a.ID, b.ID)
+		fmt.Printf("   %s: %s ← %s\n", a.ID, targetKey(a), a.ServerPath)
+		fmt.Printf("   %s: %s ← %s\n", b.ID, targetKey(b), b.ServerPath)
+
+		if filepath.Clean(a.ServerPath) != filepath.Clean(b.ServerPath) {
+			fmt.Println("   서버 경로가 다릅니다. 한쪽의 --delete가 다른 쪽이 배치한 파일을 삭제할 수 있습니다.")
+		} else if !sameRules(engine.FilterRules(a), engine.FilterRules(b)) {
+			fmt.Println("   같은 서버 경로에 서로 다른 필터를 사용합니다.")
+		}
+
+		if !withPlan {
+			fmt.Println()
+			continue
+		}
+		if a.Device.IsSet() && a.MountPoint == "" || b.Device.IsSet() && b.MountPoint == "" {
+			fmt.Println("   대상 장치가 연결되지 않아 계획을 확인할 수 없습니다.")
+			fmt.Println()
+			continue
+		}
+
+		// 실제 계획으로 서로 삭제하는 파일 확인
+		for _, pair := range [][2]*config.SyncProfile{{a, b}, {b, a}} {
+			victims, err := deletionsAgainst(engine, pair[0], pair[1])
+			if err != nil {
+				fmt.Printf("   ❌ %s 계획 실패: %v\n", pair[0].ID, err)
+				continue
+			}
+			if len(victims) == 0 {
+				continue
+			}
+			conflicts += len(victims)
+			fmt.Printf("   🗑️  %s가 %s의 파일 %d개를 삭제합니다:\n", pair[0].ID, pair[1].ID, len(victims))
+			for _, victim := range victims {
+				fmt.Printf("      - %s\n", victim)
+			}
+		}
+		fmt.Println()
+	}
+
+	if conflicts > 0 {
+		return fmt.Errorf("프로필 간 삭제 충돌 %d건", conflicts)
+	}
+	return nil
+}
+
+// findOverlaps 로컬 경로가 같거나 서로 포함되는 프로필 쌍 탐지
+func findOverlaps(cfg *config.Config) []profileOverlap {
+	overlaps := []profileOverlap{}
+	ids := cfg.ProfileIDs()
+
+	// 연결된 장치는 실제 마운트 경로로, 연결되지 않은 장치는 장치 조건으로 비교
+	profiles := make([]*config.SyncProfile, len(ids))
+	for i, id := range ids {
+		profiles[i], _ = findProfile(cfg, id)
+		if err := resolveTarget(profiles[i]); err != nil {
+			logger.Debugf("대상 장치 확인 실패: %v", err)
+		}
+	}
+
+	for i := 0; i < len(profiles); i++ {
+		for j := i + 1; j < len(profiles); j++ {
+			a, b := profiles[i], profiles[j]
+
+			_, bInA := subPath(targetKey(a), targetKey(b))
+			_, aInB := subPath(targetKey(b), targetKey(a))
+			if bInA || aInB {
+				overlaps = append(overlaps, profileOverlap{A: a, B: b})
+			}
+		}
+	}
+	return overlaps
+}
+
+// deletionsAgainst from 프로필의 계획된 삭제 중 other 프로필이 관리하는 파일 목록
+func deletionsAgainst(engine *sync.SyncEngine, from, other *config.SyncProfile) ([]string, error) {
+	changes, err := engine.DryRun(context.Background(), from)
+	if err != nil {
+		return nil, err
+	}
+
+	otherRules := engine.FilterRules(other)
+	victims := []string{}
+	for _, deletion := range changes.Deletions {
+		isDir := strings.HasSuffix(deletion, "/")
+		absolute := filepath.Join(from.LocalPath, deletion)
+
+		rel, ok := subPath(other.LocalPath, absolute)
+		if !ok || rel == "" {
+			continue
+		}
+		// 두 프로필이 같은 서버 파일을 가리키면 둘 다 삭제하는 것이므로 충돌이 아님
+		if filepath.Join(from.ServerPath, deletion) == filepath.Join(other.ServerPath, rel) {
+			continue
+		}
+		if sync.IsIncluded(otherRules, filepath.ToSlash(rel), isDir) {
+			victims = append(victims, deletion)
+		}
+	}
+	return victims, nil
+}
+
+// subPath child가 parent와 같거나 하위 경로이면 상대 경로 반환
+func subPath(parent, child string) (string, bool) {
+	rel, err := filepath.Rel(filepath.Clean(parent), filepath.Clean(child))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	if rel == "." {
+		return "", true
+	}
+	return rel, true
+}
+
+// sameRules 두 규칙 목록이 같은지 비교
+func sameRules(a, b []sync.FilterRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].String() != b[i].String() {
+			return false
+		}
+	}
+	return true
+}
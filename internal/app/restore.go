@@ -0,0 +1,119 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/sync"
+)
+
+// RestoreOptions restore 명령 옵션
+type RestoreOptions struct {
+	At   string // 이 시각에 대상에 있던 버전으로 복원 (비우면 가장 최근 백업)
+	List bool   // 복원하지 않고 백업된 버전만 표시
+}
+
+// Restore 백업된 이전 버전을 대상 경로에 복원 (네트워크 없이 동작)
+func Restore(cfg *config.Config, profileName, path string, opts RestoreOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+	if err := resolveTarget(profile); err != nil {
+		return err
+	}
+
+	rel, err := targetRelPath(profile, path)
+	if err != nil {
+		return err
+	}
+
+	engine := sync.NewSyncEngine(cfg)
+	versions, err := engine.BackupVersions(profile, rel)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return fmt.Errorf("백업된 이전 버전이 없습니다: %s (백업 위치: %s)", rel, engine.BackupRoot(profile))
+	}
+
+	if opts.List {
+		fmt.Printf("=== %s 백업 버전 ===\n", rel)
+		for _, version := range versions {
+			fmt.Printf("• %s에 교체된 버전  %s\n", version.Time.Format("2006-01-02 15:04:05"), config.FormatSize(version.Size))
+		}
+		return nil
+	}
+
+	version := versions[len(versions)-1]
+	if opts.At != "" {
+		at, err := parseRestoreTime(opts.At)
+		if err != nil {
+			return err
+		}
+		// 백업 회차 시각은 그 버전이 교체된 시각이므로, at 이후 처음 교체된 버전이 at 시점의 파일
+		found := false
+		for _, candidate := range versions {
+			if candidate.Time.After(at) {
+				version = candidate
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s 이후 교체된 적이 없어 현재 파일이 그 시점의 버전입니다: %s", at.Format("2006-01-02 15:04:05"), rel)
+		}
+	}
+
+	fmt.Printf("⏪ %s을(를) %s 이전 버전으로 복원합니다\n", rel, version.Time.Format("2006-01-02 15:04:05"))
+	if err := engine.RestoreVersion(profile, rel, version); err != nil {
+		return err
+	}
+	if err := flushTarget(profile.LocalPath, os.Stdout); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ 복원이 완료되었습니다. 복원 전 파일도 백업되어 있어 다시 되돌릴 수 있습니다.")
+	fmt.Println("   다음 동기화에서 서버 버전으로 다시 바뀌므로, 서버를 고치기 전에는 동기화하지 마세요.")
+	return nil
+}
+
+// targetRelPath 대상 경로 기준 상대 경로로 변환 (절대 경로는 대상 경로 아래여야 함)
+func targetRelPath(profile *config.SyncProfile, path string) (string, error) {
+	if filepath.IsAbs(path) {
+		rel, ok := subPath(profile.LocalPath, path)
+		if !ok || rel == "" {
+			return "", fmt.Errorf("대상 경로(%s) 아래의 경로가 아닙니다: %s", profile.LocalPath, path)
+		}
+		return rel, nil
+	}
+
+	rel := filepath.Clean(path)
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("잘못된 경로: %s", path)
+	}
+	return rel, nil
+}
+
+// parseRestoreTime --at 값 해석 (날짜/시각 또는 "2d"처럼 현재로부터의 기간)
+func parseRestoreTime(value string) (time.Time, error) {
+	layouts := []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if duration, err := config.ParseDuration(value); err == nil && duration > 0 {
+		return time.Now().Add(-duration), nil
+	}
+	return time.Time{}, fmt.Errorf("잘못된 시각: %s (예: 2026-10-01, \"2026-10-01 09:00\", 2d)", value)
+}
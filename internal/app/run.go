@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/hooks"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/state"
+	"sync-tool/internal/sync"
+)
+
+// planProfile pre_plan 훅 실행 후 드라이런으로 변경사항 계획
+func planProfile(ctx context.Context, cfg *config.Config, engine *sync.SyncEngine, profile *config.SyncProfile, out io.Writer) (*sync.SyncResult, error) {
+	if err := hooks.Run(hooks.PrePlan, profile.Hooks.PrePlan, hookVars(cfg, profile, nil, "pending", nil), out); err != nil {
+		return nil, err
+	}
+
+	changes, err := engine.DryRun(ctx, profile)
+	if err != nil {
+		return nil, fmt.Errorf("드라이런 실행 실패: %w", err)
+	}
+	return changes, nil
+}
+
+// applyPlan pre_sync 훅이 성공하면 동기화를 적용하고 post_sync/on_error 훅 실행
+// ctx가 취소되면 진행 중인 파일을 정리하고 멈춘 뒤 부분 완료 요약과 "interrupted" 기록을 남김
+func applyPlan(ctx context.Context, cfg *config.Config, engine *sync.SyncEngine, profile *config.SyncProfile, changes *sync.SyncResult, out io.Writer) error {
+	if err := hooks.Run(hooks.PreSync, profile.Hooks.PreSync, hookVars(cfg, profile, changes, "pending", nil), out); err != nil {
+		err = fmt.Errorf("pre_sync 훅으로 동기화 중단: %w", err)
+		runErrorHook(cfg, profile, changes, err, out)
+		return err
+	}
+
+	// 중단되어도 --resume으로 이어갈 수 있도록 진행 상황을 저널에 기록
+	journal := engine.Journal()
+	if journal == nil {
+		created, err := state.CreateJournal(cfg.Sync.StateDir, sync.JournalPlan(profile.ID, profile.LocalPath, changes))
+		if err != nil {
+			logger.Warnf("저널 생성 실패, 재개 기록 없이 진행합니다: %v", err)
+		} else {
+			journal = created
+			engine.SetJournal(journal)
+		}
+	}
+
+	start := time.Now()
+	syncErr := engine.Sync(ctx, profile, changes)
+	if journal != nil {
+		engine.SetJournal(nil)
+		if syncErr == nil {
+			if err := journal.Remove(); err != nil {
+				logger.Warnf("%v", err)
+			}
+		} else {
+			journal.Close()
+		}
+	}
+
+	stopped := interrupted(syncErr)
+	switch {
+	case stopped:
+		fmt.Fprintf(out, "⏹️  동기화가 중단되었습니다: 복사 %d/%d개, 삭제 %d/%d개 완료\n",
+			changes.Copied, len(changes.Changes), changes.Removed, len(changes.Deletions))
+		syncErr = fmt.Errorf("동기화 중단됨: %w", syncErr)
+		// 중단되어도 이미 복사한 파일은 USB에 기록해 둠
+		if err := flushTarget(profile.LocalPath, out); err != nil {
+			logger.Warnf("%v", err)
+		}
+	case syncErr != nil:
+		syncErr = fmt.Errorf("동기화 실행 실패: %w", syncErr)
+	default:
+		// 완료를 알리기 전에 페이지 캐시의 데이터를 USB에 기록
+		syncErr = flushTarget(profile.LocalPath, out)
+	}
+	recordSynced(cfg, engine, profile, changes, syncErr, out)
+	recordSync(cfg, profile, changes, start, syncErr)
+
+	result := state.ResultSuccess
+	switch {
+	case stopped:
+		result = state.ResultInterrupted
+	case syncErr != nil:
+		result = state.ResultFailure
+	}
+	if err := hooks.Run(hooks.PostSync, profile.Hooks.PostSync, hookVars(cfg, profile, changes, result, syncErr), out); err != nil {
+		logger.Warnf("post_sync 훅 실패: %v", err)
+	}
+
+	// 사용자가 중단한 것은 오류가 아니므로 on_error 훅은 실행하지 않음
+	if stopped {
+		return syncErr
+	}
+	if syncErr != nil {
+		runErrorHook(cfg, profile, changes, syncErr, out)
+		return syncErr
+	}
+
+	if profile.Eject {
+		if err := ejectTarget(profile, out); err != nil {
+			return fmt.Errorf("볼륨 분리 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+// runErrorHook on_error 훅 실행 (훅 자체의 실패는 경고만 기록)
+func runErrorHook(cfg *config.Config, profile *config.SyncProfile, changes *sync.SyncResult, cause error, out io.Writer) {
+	if err := hooks.Run(hooks.OnError, profile.Hooks.OnError, hookVars(cfg, profile, changes, "failure", cause), out); err != nil {
+		logger.Warnf("on_error 훅 실패: %v", err)
+	}
+}
+
+// hookVars 훅에 전달할 SYNC_* 환경 변수
+func hookVars(cfg *config.Config, profile *config.SyncProfile, changes *sync.SyncResult, result string, cause error) map[string]string {
+	vars := map[string]string{
+		"SYNC_PROFILE":      profile.ID,
+		"SYNC_PROFILE_NAME": profile.Name,
+		"SYNC_SERVER_HOST":  cfg.Server.Host,
+		"SYNC_SERVER_PATH":  profile.ServerPath,
+		"SYNC_LOCAL_PATH":   profile.LocalPath,
+		"SYNC_MOUNT_POINT":  profile.MountPoint,
+		"SYNC_RESULT":       result,
+		"SYNC_CHANGES":      "0",
+		"SYNC_DELETIONS":    "0",
+		"SYNC_BYTES":        "0",
+		"SYNC_ATTEMPTS":     "0",
+		"SYNC_ERROR":        "",
+	}
+	if changes != nil {
+		vars["SYNC_CHANGES"] = strconv.Itoa(len(changes.Changes))
+		vars["SYNC_DELETIONS"] = strconv.Itoa(len(changes.Deletions))
+		vars["SYNC_BYTES"] = strconv.FormatInt(changes.TotalBytes, 10)
+		vars["SYNC_ATTEMPTS"] = strconv.Itoa(changes.Attempts)
+	}
+	if cause != nil {
+		vars["SYNC_ERROR"] = cause.Error()
+	}
+	return vars
+}
@@ -0,0 +1,298 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/sync"
+)
+
+// ScriptOptions script 명령 옵션
+type ScriptOptions struct {
+	Output string // 스크립트 파일 경로 (비우면 표준 출력)
+}
+
+// Script 프로필과 같은 rsync 동기화를 수행하는 POSIX 셸 스크립트 생성
+// 같은 설정이면 항상 같은 스크립트가 나오도록 생성 시각이나 호스트별 정보는 넣지 않음
+func Script(cfg *config.Config, profileName string, opts ScriptOptions) error {
+	// 스크립트를 표준 출력으로 내보낼 수 있도록 로거는 초기화하지 않음 (기본 로거는 표준 에러로 출력)
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+
+	engine := sync.NewSyncEngine(cfg)
+	if err := engine.ValidateSettings(profile); err != nil {
+		return fmt.Errorf("프로필 유효성 검사 실패: %w", err)
+	}
+
+	if opts.Output == "" {
+		return writeScript(os.Stdout, engine, profile)
+	}
+
+	file, err := os.OpenFile(opts.Output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return fmt.Errorf("스크립트 파일 생성 실패: %w", err)
+	}
+	if err := writeScript(file, engine, profile); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("스크립트 파일 저장 실패: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ 스크립트 저장: %s\n", opts.Output)
+	return nil
+}
+
+// writeScript 셸 스크립트 내용 출력
+func writeScript(w io.Writer, engine *sync.SyncEngine, profile *config.SyncProfile) error {
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		fmt.Fprintf(&b, format+"\n", args...)
+	}
+
+	source := engine.RemoteSource(profile)
+	target := profile.LocalPath
+	if profile.Device.IsSet() {
+		// 장치 프로필의 로컬 경로는 볼륨 기준 상대 경로라 실행할 때 대상을 지정해야 함
+		target = ""
+	}
+
+	line("#!/bin/sh")
+	line("# %s 프로필 동기화 스크립트 (sync-tool script %s로 생성)", profile.ID, profile.ID)
+	line("#")
+	if profile.Name != "" {
+		line("# 이름: %s", commentText(profile.Name))
+	}
+	if profile.Description != "" {
+		line("# 설명: %s", commentText(profile.Description))
+	}
+	line("# 소스: %s", commentText(source))
+	if profile.Device.IsSet() {
+		line("# 대상: 장치 %s 볼륨의 %s (실행할 때 경로 지정)", commentText(profile.Device.String()), commentText(profile.LocalPath))
+	} else {
+		line("# 대상: %s", commentText(target))
+	}
+	line("#")
+	line("# 필터 규칙 (위에서부터 처음 일치하는 규칙이 적용됨):")
+	rules := engine.FilterRules(profile)
+	if len(rules) == 0 {
+		line("#   (없음)")
+	}
+	for _, rule := range rules {
+		line("#   %-40s %s", commentText(rule.String()), commentText(rule.Source))
+	}
+	line("#")
+	line("# 사용법: sh %s-sync.sh [--dry-run] [--yes] [대상 경로]", profile.ID)
+	line("#   --dry-run  변경하지 않고 rsync가 할 일만 표시")
+	line("#   --yes      확인 없이 실행")
+	if profile.Device.IsSet() {
+		line("#   --no-device-check  대상 볼륨의 라벨/UUID 확인 건너뛰기")
+	}
+	line("#")
+	line("# SSH 키 경로는 스크립트에 넣지 않으므로 ~/.ssh/config나 SYNC_SSH 환경 변수로 지정하세요")
+	line("#   예: SYNC_SSH='%s -i ~/.ssh/id_ed25519' sh %s-sync.sh", engine.PortableSSHCommand(profile), profile.ID)
+	line("#")
+	line("# sync-tool의 캐시, 스테이징, 백업, 재시도, 재개 저널과 훅은 이 스크립트에 포함되지 않습니다.")
+	line("set -eu")
+	line("")
+	line("SOURCE=%s", shellQuote(source))
+	line("TARGET=%s", shellQuote(target))
+	line("SSH=%s", shellQuote(engine.PortableSSHCommand(profile)))
+	line(`if [ -n "${SYNC_SSH:-}" ]; then`)
+	line(`	SSH=$SYNC_SSH`)
+	line(`fi`)
+	if profile.Device.IsSet() {
+		line("DEVICE_LABEL=%s", shellQuote(udevLabelPattern(profile.Device.Label)))
+		line("DEVICE_UUID=%s", shellQuote(strings.ToLower(profile.Device.UUID)))
+		line("DEVICE_DESC=%s", shellQuote(profile.Device.String()))
+		line("CHECK_DEVICE=1")
+	}
+	line("DRY_RUN=0")
+	line("ASSUME_YES=0")
+	line("")
+	line(`while [ $# -gt 0 ]; do`)
+	line(`	case "$1" in`)
+	line(`	--dry-run) DRY_RUN=1 ;;`)
+	line(`	--yes) ASSUME_YES=1 ;;`)
+	if profile.Device.IsSet() {
+		line(`	--no-device-check) CHECK_DEVICE=0 ;;`)
+	}
+	line(`	-*) echo "알 수 없는 옵션: $1" >&2; exit 2 ;;`)
+	line(`	*) TARGET=$1 ;;`)
+	line(`	esac`)
+	line(`	shift`)
+	line(`done`)
+	line("")
+	line("# 안전 확인: rsync 설치, 대상 경로 지정과 존재, 루트 디렉토리 보호")
+	line(`if ! command -v rsync >/dev/null 2>&1; then`)
+	line(`	echo "rsync를 찾을 수 없습니다" >&2`)
+	line(`	exit 1`)
+	line(`fi`)
+	line(`if [ -z "$TARGET" ]; then`)
+	line(`	echo "대상 경로를 지정하세요" >&2`)
+	line(`	exit 2`)
+	line(`fi`)
+	line(`if [ ! -d "$TARGET" ]; then`)
+	line(`	echo "대상 경로가 존재하지 않습니다: $TARGET" >&2`)
+	line(`	exit 1`)
+	line(`fi`)
+	line("# //, /., 심볼릭 링크처럼 루트를 가리키는 다른 표기도 걸러지도록 실제 경로로 바꾼 뒤 확인")
+	line(`TARGET=$(CDPATH= cd -- "$TARGET" && pwd -P)`)
+	line(`if [ "$TARGET" = / ]; then`)
+	line(`	echo "루트 디렉토리에는 동기화할 수 없습니다" >&2`)
+	line(`	exit 1`)
+	line(`fi`)
+	line("")
+	if profile.Device.IsSet() {
+		writeDeviceCheck(line, profile.Device)
+	}
+	line("# rsync 옵션 (--delete: 서버에 없는 파일은 대상에서 삭제됨)")
+	// -e는 호스트별 키 경로가 들어가지 않도록 아래에서 $SSH로 지정
+	options := engine.RsyncOptions(profile, false, false)
+	line(`set -- \`)
+	for i, arg := range options {
+		if i < len(options)-1 {
+			line(`	%s \`, shellQuote(arg))
+		} else {
+			line(`	%s`, shellQuote(arg))
+		}
+	}
+	line(`set -- "$@" -e "$SSH"`)
+	line("")
+	line(`if [ "$DRY_RUN" = 1 ]; then`)
+	line(`	set -- "$@" --dry-run`)
+	line(`elif [ "$ASSUME_YES" != 1 ]; then`)
+	line(`	if [ ! -t 0 ]; then`)
+	line(`		echo "확인 없이 실행하려면 --yes를 지정하세요" >&2`)
+	line(`		exit 2`)
+	line(`	fi`)
+	line(`	printf '%%s를 %%s와 동기화합니다. 서버에 없는 파일은 삭제됩니다. 계속할까요? (y/n): ' "$TARGET" "$SOURCE"`)
+	line(`	read -r answer`)
+	line(`	case "$answer" in`)
+	line(`	y | Y | yes) ;;`)
+	line(`	*)`)
+	line(`		echo "동기화가 취소되었습니다."`)
+	line(`		exit 0`)
+	line(`		;;`)
+	line(`	esac`)
+	line(`fi`)
+	line("")
+	line(`set -- rsync "$@" "$SOURCE" "$TARGET/"`)
+
+	ionice, nice := engine.PriorityArgs(profile)
+	if len(nice) > 0 || len(ionice) > 0 {
+		line("")
+		line("# CPU/I/O 우선순위 (transfer.nice, transfer.ionice)")
+	}
+	if len(nice) > 0 {
+		line(`set -- %s "$@"`, shellJoin(nice))
+	}
+	if len(ionice) > 0 {
+		line(`if command -v ionice >/dev/null 2>&1; then`)
+		line(`	set -- %s "$@"`, shellJoin(ionice))
+		line(`fi`)
+	}
+	line("")
+	line(`exec "$@"`)
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("스크립트 출력 실패: %w", err)
+	}
+	return nil
+}
+
+// writeDeviceCheck 대상이 있는 볼륨이 프로필의 장치 조건과 같은지 /dev/disk/by-label, by-uuid로 확인하는 부분 출력
+// by-label 이름은 udev가 인코딩한 형태이므로 DEVICE_LABEL도 같은 형태의 소문자 case 패턴으로 넣음
+func writeDeviceCheck(line func(string, ...interface{}), match config.DeviceMatch) {
+	line("# 장치 확인: 대상이 있는 볼륨의 라벨/UUID가 %s와 같은지 확인 (Linux의 /dev/disk 필요)", commentText(match.String()))
+	line(`if [ "$CHECK_DEVICE" = 1 ]; then`)
+	line(`	if [ ! -d /dev/disk/by-uuid ] && [ ! -d /dev/disk/by-label ]; then`)
+	line(`		echo "대상 볼륨을 확인할 수 없습니다 (/dev/disk 없음). 확인 없이 실행하려면 --no-device-check" >&2`)
+	line(`		exit 1`)
+	line(`	fi`)
+	line(`	volume=$(df -P "$TARGET" | awk 'NR == 2 { print $1 }')`)
+	line(`	volume=$(readlink -f "$volume" 2>/dev/null || echo "$volume")`)
+	line(`	volume_label=''`)
+	line(`	volume_uuid=''`)
+	line(`	for link in /dev/disk/by-label/* /dev/disk/by-uuid/*; do`)
+	line(`		[ -e "$link" ] || continue`)
+	line(`		[ "$(readlink -f "$link")" = "$volume" ] || continue`)
+	line(`		name=$(printf '%%s' "${link##*/}" | tr '[:upper:]' '[:lower:]')`)
+	line(`		case "$link" in`)
+	line(`		/dev/disk/by-label/*) volume_label=$name ;;`)
+	line(`		*) volume_uuid=$name ;;`)
+	line(`		esac`)
+	line(`	done`)
+	line(`	matched=1`)
+	line(`	if [ -n "$DEVICE_UUID" ] && [ "$volume_uuid" != "$DEVICE_UUID" ]; then`)
+	line(`		matched=0`)
+	line(`	fi`)
+	line(`	if [ -n "$DEVICE_LABEL" ]; then`)
+	line(`		case "$volume_label" in`)
+	line(`		$DEVICE_LABEL) ;;`)
+	line(`		*) matched=0 ;;`)
+	line(`		esac`)
+	line(`	fi`)
+	line(`	if [ "$matched" != 1 ]; then`)
+	line(`		echo "대상 볼륨($volume)이 장치 조건($DEVICE_DESC)과 다릅니다. 확인 없이 실행하려면 --no-device-check" >&2`)
+	line(`		exit 1`)
+	line(`	fi`)
+	line(`fi`)
+	line("")
+}
+
+// udevLabelPattern /dev/disk/by-label 이름과 비교할 셸 case 패턴
+// udev처럼 허용되지 않는 문자는 \xNN으로 인코딩하고 (case 패턴에서 백슬래시 자체와 일치하도록 \\로 씀),
+// 라벨 조건처럼 대소문자를 무시하도록 소문자로 변환. 글롭 문자 *, ?, [, ]는 그대로 둠
+func udevLabelPattern(label string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(label) {
+		if r >= utf8.RuneSelf || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || strings.ContainsRune("#+-.:=@_*?[]", r) {
+			b.WriteRune(r)
+			continue
+		}
+		for _, c := range []byte(string(r)) {
+			fmt.Fprintf(&b, `\\x%02x`, c)
+		}
+	}
+	return b.String()
+}
+
+// commentText 주석 한 줄에 넣을 수 있도록 줄바꿈 제거 (설정 값이 명령으로 실행되지 않도록)
+func commentText(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// shellJoin 인자 목록을 셸 단어로 인용하여 연결
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote POSIX 셸에서 그대로 전달되도록 인용 (안전한 문자만 있으면 그대로)
+func shellQuote(value string) string {
+	if value == "" {
+		return "''"
+	}
+	safe := true
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("@%+=:,./_-", r)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,49 @@
+package app
+
+import "testing"
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", "''"},
+		{"abc", "abc"},
+		{"--timeout=300", "--timeout=300"},
+		{"u@h:/srv/a/", "u@h:/srv/a/"},
+		{"@%+=:,./_-", "@%+=:,./_-"},
+		{"a b", "'a b'"},
+		{"--filter=P /x/", "'--filter=P /x/'"},
+		{"$HOME", "'$HOME'"},
+		{"*.iso", "'*.iso'"},
+		{"it's", `'it'\''s'`},
+		{"한글", "'한글'"},
+		{"a\nb", "'a\nb'"},
+	}
+
+	for _, tt := range tests {
+		if got := shellQuote(tt.value); got != tt.want {
+			t.Errorf("shellQuote(%q) = %q, 기대값 %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestUdevLabelPattern(t *testing.T) {
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{"", ""},
+		{"VENTOY", "ventoy"},
+		{"My Stick*", `my\\x20stick*`},
+		{"a/b", `a\\x2fb`},
+		{"USB_2.0-[AB]?", "usb_2.0-[ab]?"},
+		{"한글", "한글"},
+	}
+
+	for _, tt := range tests {
+		if got := udevLabelPattern(tt.label); got != tt.want {
+			t.Errorf("udevLabelPattern(%q) = %q, 기대값 %q", tt.label, got, tt.want)
+		}
+	}
+}
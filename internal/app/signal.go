@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// interruptContext Ctrl-C(SIGINT)나 SIGTERM을 받으면 취소되는 컨텍스트
+// 첫 번째 신호는 진행 중인 작업을 정리하고 종료하도록 하고, 두 번째 신호는 기본 동작대로 즉시 종료
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			signal.Stop(signals)
+			fmt.Fprintln(os.Stderr, "\n⏹️  중단 요청을 받았습니다. 진행 중인 작업을 정리합니다... (즉시 종료: Ctrl-C 한 번 더)")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
+// interrupted 중단 요청으로 끝난 오류인지 확인
+func interrupted(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,269 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/manifest"
+	"sync-tool/internal/state"
+	"sync-tool/internal/sync"
+)
+
+// TagOptions tag 명령 옵션
+type TagOptions struct {
+	Force bool // 같은 이름의 태그가 있으면 덮어쓰기
+}
+
+// CheckoutOptions checkout 명령 옵션
+type CheckoutOptions struct {
+	DryRun      bool
+	AutoConfirm bool
+}
+
+// Tag 대상의 현재 파일 목록과 해시를 이름 붙은 스냅샷으로 기록 (이름이 없으면 태그 목록 표시)
+func Tag(cfg *config.Config, profileName, name string, opts TagOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		return showTags(cfg, profile)
+	}
+
+	if err := resolveTarget(profile); err != nil {
+		return err
+	}
+	if _, err := os.Stat(profile.LocalPath); err != nil {
+		return fmt.Errorf("로컬 경로가 존재하지 않습니다: %s", profile.LocalPath)
+	}
+	if _, err := os.Stat(state.TagPath(cfg.Sync.StateDir, profile.ID, name)); err == nil && !opts.Force {
+		return fmt.Errorf("이미 있는 태그입니다: %s (덮어쓰려면 --force)", name)
+	}
+
+	fmt.Printf("🔍 %s의 파일 해시 계산 중...\n", profile.LocalPath)
+	engine := sync.NewSyncEngine(cfg)
+	m, err := manifest.Build(profile.LocalPath, engine.ArtifactDirs(profile))
+	if err != nil {
+		return err
+	}
+	m.Profile = profile.ID
+
+	if err := state.SaveTag(cfg.Sync.StateDir, profile.ID, name, m); err != nil {
+		return err
+	}
+
+	fmt.Printf("🏷️  태그 저장: %s (파일 %d개, %s)\n", name, len(m.Entries), config.FormatSize(m.TotalSize()))
+	return nil
+}
+
+// Checkout 대상을 태그에 기록된 상태와 똑같이 되돌림 (내용은 캐시와 백업에서 가져옴)
+func Checkout(cfg *config.Config, profileName, tag string, opts CheckoutOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+	if err := resolveTarget(profile); err != nil {
+		return err
+	}
+	if _, err := os.Stat(profile.LocalPath); err != nil {
+		return fmt.Errorf("로컬 경로가 존재하지 않습니다: %s", profile.LocalPath)
+	}
+
+	m, err := state.LoadTag(cfg.Sync.StateDir, profile.ID, tag)
+	if err != nil {
+		return err
+	}
+
+	engine := sync.NewSyncEngine(cfg)
+	steps, missing, err := engine.PlanCheckout(profile, m)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		fmt.Printf("❌ 캐시와 백업에서 내용을 찾을 수 없는 파일 %d개:\n", len(missing))
+		for _, path := range missing {
+			fmt.Printf("   %s\n", path)
+		}
+		return fmt.Errorf("태그 %s로 되돌릴 수 없습니다 (fetch로 캐시를 채우거나 backup을 켜 두세요)", tag)
+	}
+
+	copies, deletions := 0, 0
+	for _, step := range steps {
+		if step.Action == sync.CheckoutCopy {
+			copies++
+		} else {
+			deletions++
+		}
+	}
+
+	fmt.Printf("=== 체크아웃: %s → %s (%s에 기록) ===\n", tag, profile.LocalPath, m.Created.Format("2006-01-02 15:04:05"))
+	if len(steps) == 0 {
+		fmt.Println("✅ 이미 태그와 같은 상태입니다.")
+		return nil
+	}
+	fmt.Printf("복원할 파일: %d개, 삭제할 파일: %d개\n", copies, deletions)
+
+	if opts.DryRun {
+		for _, step := range steps {
+			if step.Action == sync.CheckoutCopy {
+				fmt.Printf("📄 %s ← %s\n", step.Path, step.Source)
+			} else {
+				fmt.Printf("🗑️  %s\n", step.Path)
+			}
+		}
+		fmt.Println("드라이런 모드로 실행되었습니다. 실제로 변경하지 않았습니다.")
+		return nil
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	if !opts.AutoConfirm && cfg.UI.ConfirmActions {
+		confirmed, err := askYesNo(ctx, fmt.Sprintf("%s를 태그 %s 상태로 되돌리시겠습니까? (y/n): ", profile.LocalPath, tag))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("체크아웃이 취소되었습니다.")
+			return nil
+		}
+	}
+
+	if err := engine.ApplyCheckout(ctx, profile, steps); err != nil {
+		if interrupted(err) {
+			fmt.Println("⏹️  체크아웃이 중단되었습니다. 다시 실행하면 남은 파일을 되돌립니다.")
+		}
+		return fmt.Errorf("체크아웃 실패: %w", err)
+	}
+	if err := flushTarget(profile.LocalPath, os.Stdout); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ %s가 태그 %s 상태가 되었습니다.\n", profile.LocalPath, tag)
+	return nil
+}
+
+// CheckoutPaths 지정한 파일이나 디렉토리만 서버에서 받아옴 (나머지 변경사항은 무시하고 아무것도 삭제하지 않음)
+// 전체 동기화가 아니므로 훅은 실행하지 않고, 실행 기록에는 checkout으로 남기며 장치의 마지막 동기화 시각은 바꾸지 않음
+func CheckoutPaths(cfg *config.Config, profileName string, paths []string, opts CheckoutOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+	if err := resolveTarget(profile); err != nil {
+		return err
+	}
+
+	wanted := make([]string, 0, len(paths))
+	for _, path := range paths {
+		rel, err := targetRelPath(profile, path)
+		if err != nil {
+			return err
+		}
+		wanted = append(wanted, filepath.ToSlash(rel))
+	}
+
+	// 캐시가 아니라 서버의 현재 내용을 받음
+	engine := sync.NewSyncEngine(cfg)
+	engine.SetUseCache(false)
+	if err := engine.ValidateProfile(profile); err != nil {
+		return fmt.Errorf("프로필 유효성 검사 실패: %w", err)
+	}
+
+	plan, err := engine.DryRunPaths(ctx, profile, wanted)
+	if err != nil {
+		return fmt.Errorf("드라이런 실행 실패: %w", err)
+	}
+	changes, unmatched := sync.SelectPaths(plan, wanted)
+
+	for _, path := range unmatched {
+		fmt.Printf("⚠️  받을 내용이 없습니다: %s (이미 최신이거나 서버에 없거나 필터로 제외됨)\n", path)
+	}
+	showChanges(changes)
+
+	if !changes.HasChanges {
+		fmt.Println("✅ 받을 파일이 없습니다.")
+		return nil
+	}
+
+	if opts.DryRun {
+		fmt.Println("드라이런 모드로 실행되었습니다. 실제로 변경하지 않았습니다.")
+		return nil
+	}
+
+	if !opts.AutoConfirm && cfg.UI.ConfirmActions {
+		confirmed, err := askYesNo(ctx, "위 파일들을 서버에서 받으시겠습니까? (y/n): ")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("체크아웃이 취소되었습니다.")
+			return nil
+		}
+	}
+
+	start := time.Now()
+	syncErr := engine.Sync(ctx, profile, changes)
+	switch {
+	case interrupted(syncErr):
+		fmt.Printf("⏹️  체크아웃이 중단되었습니다: 복사 %d/%d개 완료\n", changes.Copied, len(changes.Changes))
+		syncErr = fmt.Errorf("체크아웃 중단됨: %w", syncErr)
+	case syncErr != nil:
+		syncErr = fmt.Errorf("체크아웃 실패: %w", syncErr)
+	}
+	if err := flushTarget(profile.LocalPath, os.Stdout); err != nil && syncErr == nil {
+		syncErr = err
+	}
+	recordCheckout(cfg, profile, changes, start, syncErr)
+	if syncErr != nil {
+		return syncErr
+	}
+
+	fmt.Printf("✅ %d개 항목을 서버에서 받았습니다. 나머지 변경사항은 그대로 남아 있습니다.\n", len(changes.Changes))
+	return nil
+}
+
+// showTags 프로필의 태그 목록 표시
+func showTags(cfg *config.Config, profile *config.SyncProfile) error {
+	names, err := state.ListTags(cfg.Sync.StateDir, profile.ID)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Printf("%s 프로필에 태그가 없습니다.\n", profile.ID)
+		return nil
+	}
+
+	fmt.Printf("=== %s 태그 ===\n", profile.ID)
+	for _, name := range names {
+		m, err := state.LoadTag(cfg.Sync.StateDir, profile.ID, name)
+		if err != nil {
+			fmt.Printf("• %s: ❌ %v\n", name, err)
+			continue
+		}
+		fmt.Printf("• %s: %s, 파일 %d개, %s\n",
+			name, m.Created.Format("2006-01-02 15:04:05"), len(m.Entries), config.FormatSize(m.TotalSize()))
+	}
+	return nil
+}
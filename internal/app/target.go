@@ -0,0 +1,73 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/device"
+	"sync-tool/internal/logger"
+)
+
+// resolveTarget 장치 식별 조건이 있는 프로필의 로컬 경로를 현재 마운트 위치로 결정
+func resolveTarget(profile *config.SyncProfile) error {
+	if !profile.Device.IsSet() || profile.MountPoint != "" {
+		return nil
+	}
+	if filepath.IsAbs(profile.LocalPath) {
+		return fmt.Errorf("device를 사용하는 프로필의 local_path는 볼륨 루트 기준 상대 경로여야 합니다: %s", profile.LocalPath)
+	}
+
+	volume, err := device.Resolve(profile.Device.Label, profile.Device.UUID)
+	if err != nil {
+		return fmt.Errorf("%s 프로필 대상 장치: %w", profile.ID, err)
+	}
+
+	logger.Debugf("대상 장치 확인: %s → %s (%s)", profile.Device, volume.Point, volume.Device)
+	bindTarget(profile, volume.Point)
+	return nil
+}
+
+// bindTarget 볼륨 마운트 경로를 기준으로 프로필의 로컬 경로 설정
+func bindTarget(profile *config.SyncProfile, mountPoint string) {
+	profile.LocalPath = filepath.Join(mountPoint, profile.LocalPath)
+	profile.MountPoint = mountPoint
+}
+
+// targetKey 대상 비교용 경로 (장치를 찾지 못한 프로필은 장치 조건 기준의 가상 경로)
+func targetKey(profile *config.SyncProfile) string {
+	if profile.Device.IsSet() && profile.MountPoint == "" {
+		return filepath.Join("["+profile.Device.String()+"]", profile.LocalPath)
+	}
+	return profile.LocalPath
+}
+
+// expandVolumeTarget "label:패턴" 또는 "uuid:값" 대상을 마운트 경로 목록으로 변환
+func expandVolumeTarget(target string) ([]string, bool, error) {
+	kind, value, found := strings.Cut(target, ":")
+	if !found || (kind != "label" && kind != "uuid") {
+		return nil, false, nil
+	}
+
+	volumes, err := device.Volumes()
+	if err != nil {
+		return nil, true, err
+	}
+
+	label, uuid := value, ""
+	if kind == "uuid" {
+		label, uuid = "", value
+	}
+
+	points := []string{}
+	for _, volume := range volumes {
+		if device.Matches(volume, label, uuid) {
+			points = append(points, volume.Point)
+		}
+	}
+	if len(points) == 0 {
+		return nil, true, fmt.Errorf("조건과 일치하는 장치가 없습니다: %s", target)
+	}
+	return points, true, nil
+}
@@ -0,0 +1,248 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchOptions watch 실행 옵션
+type WatchOptions struct {
+	Interval   time.Duration // 서버 변경사항 확인 주기
+	Debounce   time.Duration // 로컬 변경 후 동기화까지 대기 시간
+	MaxBackoff time.Duration // 실패 시 최대 대기 시간
+	UseCache   bool          // fetch 캐시 사용 (캐시는 따로 fetch해야 갱신되므로 기본은 서버에서 직접)
+}
+
+// profileWatcher 프로필 하나를 계속 서버와 일치하도록 유지
+type profileWatcher struct {
+	cfg     *config.Config
+	profile *config.SyncProfile
+	opts    WatchOptions
+	engine  *sync.SyncEngine
+
+	cycle    int
+	failures int
+	written  map[string]bool // 마지막 주기에서 동기화가 쓰거나 지운 경로 (자기 변경 이벤트 무시용)
+}
+
+// Watch 주기적인 드라이런과 로컬 변경 감지로 프로필을 계속 자동 동기화
+func Watch(cfg *config.Config, profileName string, opts WatchOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	profile, err := findProfile(cfg, profileName)
+	if err != nil {
+		return err
+	}
+	if err := resolveTarget(profile); err != nil {
+		return err
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Minute
+	}
+	if opts.Debounce <= 0 {
+		opts.Debounce = 5 * time.Second
+	}
+	if opts.MaxBackoff < opts.Interval {
+		opts.MaxBackoff = opts.Interval
+	}
+
+	w := &profileWatcher{cfg: cfg, profile: profile, opts: opts, engine: sync.NewSyncEngine(cfg)}
+	w.engine.SetUseCache(opts.UseCache)
+	if err := w.engine.ValidateProfile(profile); err != nil {
+		return fmt.Errorf("프로필 유효성 검사 실패: %w", err)
+	}
+
+	artifacts := w.engine.ArtifactDirs(profile)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("로컬 변경 감지 시작 실패: %w", err)
+	}
+	defer watcher.Close()
+	if err := addWatchTree(watcher, profile.LocalPath, artifacts); err != nil {
+		return err
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	fmt.Printf("👀 %s 감시 시작: %s ← %s (주기: %v, 종료: Ctrl-C)\n",
+		profile.ID, profile.LocalPath, profile.ServerPath, opts.Interval)
+
+	next := time.NewTimer(0)
+	defer next.Stop()
+	debounce := time.NewTimer(time.Hour)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	var notBefore, quietUntil time.Time
+	reason := "start"
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println("감시를 종료합니다.")
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if isSyncArtifact(event.Name, artifacts) {
+				continue
+			}
+			// 동기화가 만든 디렉토리도 이후 변경을 감지하도록 감시에 추가
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := addWatchTree(watcher, event.Name, artifacts); err != nil {
+						logger.Warnf("하위 디렉토리 감시 추가 실패: %v", err)
+					}
+				}
+			}
+			// 방금 동기화가 쓰거나 지운 경로의 이벤트만 무시하고, 그 사이 사용자가 바꾼 파일은 처리
+			if time.Now().Before(quietUntil) && w.written[filepath.Clean(event.Name)] {
+				continue
+			}
+			logger.Debugf("로컬 변경 감지: %s %s", event.Op, event.Name)
+			debounce.Reset(opts.Debounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Warnf("로컬 변경 감지 오류: %v", err)
+
+		case <-debounce.C:
+			// 실패 후 대기 중이면 대기가 끝날 때 한 번에 처리
+			if wait := time.Until(notBefore); wait > 0 {
+				reason = "local-change"
+				next.Reset(wait)
+				continue
+			}
+			reason = "local-change"
+			next.Reset(0)
+
+		case <-next.C:
+			delay := w.runCycle(ctx, reason)
+			if ctx.Err() != nil {
+				fmt.Println("감시를 종료합니다.")
+				return nil
+			}
+			// 동기화로 생긴 로컬 변경 이벤트는 잠시 무시
+			quietUntil = time.Now().Add(opts.Debounce)
+			notBefore = time.Time{}
+			if w.failures > 0 {
+				notBefore = time.Now().Add(delay)
+			}
+			reason = "interval"
+			next.Reset(delay)
+		}
+	}
+}
+
+// runCycle 계획과 적용을 한 번 실행하고 다음 실행까지의 대기 시간 반환
+func (w *profileWatcher) runCycle(ctx context.Context, reason string) time.Duration {
+	w.cycle++
+	start := time.Now()
+	fields := logger.Fields{
+		"profile": w.profile.ID,
+		"target":  w.profile.LocalPath,
+		"cycle":   w.cycle,
+		"reason":  reason,
+	}
+
+	changes, err := planProfile(ctx, w.cfg, w.engine, w.profile, os.Stdout)
+	if err == nil && (changes.HasChanges || changes.HasDeletions) {
+		fields["changes"] = len(changes.Changes)
+		fields["deletions"] = len(changes.Deletions)
+		fields["bytes"] = changes.TotalBytes
+		w.written = writtenPaths(w.profile, changes)
+		err = applyPlan(ctx, w.cfg, w.engine, w.profile, changes, os.Stdout)
+	}
+	fields["duration"] = time.Since(start).Round(time.Millisecond).String()
+
+	if err != nil {
+		w.failures++
+		delay := w.backoff()
+		fields["failures"] = w.failures
+		fields["retry_in"] = delay.String()
+		logger.WithFields(fields).Errorf("감시 주기 실패: %v", err)
+		return delay
+	}
+
+	w.failures = 0
+	if changes.HasChanges || changes.HasDeletions {
+		logger.WithFields(fields).Info("감시 주기 동기화 완료")
+	} else {
+		logger.WithFields(fields).Info("감시 주기: 변경사항 없음")
+	}
+	return w.opts.Interval
+}
+
+// writtenPaths 동기화 계획이 대상에서 쓰거나 지우는 경로 (절대 경로)
+func writtenPaths(profile *config.SyncProfile, changes *sync.SyncResult) map[string]bool {
+	paths := make(map[string]bool, len(changes.Changes)+len(changes.Deletions))
+	for _, change := range changes.Changes {
+		paths[filepath.Join(profile.LocalPath, filepath.FromSlash(change.Path))] = true
+	}
+	for _, path := range changes.Deletions {
+		paths[filepath.Join(profile.LocalPath, filepath.FromSlash(path))] = true
+	}
+	return paths
+}
+
+// backoff 연속 실패 횟수에 따라 두 배씩 늘어나는 대기 시간 (최대 MaxBackoff)
+func (w *profileWatcher) backoff() time.Duration {
+	delay := w.opts.Interval
+	for i := 1; i < w.failures && delay < w.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > w.opts.MaxBackoff {
+		delay = w.opts.MaxBackoff
+	}
+	return delay
+}
+
+// addWatchTree 디렉토리와 모든 하위 디렉토리를 감시 대상에 추가
+func addWatchTree(watcher *fsnotify.Watcher, root string, artifacts []string) error {
+	return filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if isSyncArtifact(path, artifacts) {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("감시 추가 실패: %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// isSyncArtifact 동기화가 만드는 부분 전송, 스테이징, 백업 경로인지 확인
+func isSyncArtifact(path string, artifacts []string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		for _, artifact := range artifacts {
+			if part == artifact {
+				return true
+			}
+		}
+	}
+	return false
+}
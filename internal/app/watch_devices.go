@@ -0,0 +1,229 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	gosync "sync"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/device"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/notify"
+	"sync-tool/internal/sync"
+)
+
+// WatchDevicesOptions watch-devices 실행 옵션
+type WatchDevicesOptions struct {
+	Interval time.Duration
+	NoCache  bool
+}
+
+// deviceWatcher 연결된 볼륨을 추적하고 프로필 자동 동기화를 실행
+type deviceWatcher struct {
+	cfg      *config.Config
+	opts     WatchDevicesOptions
+	profiles []*config.SyncProfile
+
+	mu       gosync.Mutex
+	busy     map[string]bool // 동기화 중인 마운트 경로
+	promptMu gosync.Mutex    // 터미널 확인 질문이 섞이지 않도록 사용
+	outputMu gosync.Mutex
+	wg       gosync.WaitGroup
+}
+
+// WatchDevices 마운트 테이블을 주기적으로 확인하여 알려진 USB가 연결되면 auto 정책에 따라 동기화
+func WatchDevices(cfg *config.Config, opts WatchDevicesOptions) error {
+	if err := logger.Init(&cfg.Logging); err != nil {
+		return fmt.Errorf("로거 초기화 실패: %w", err)
+	}
+
+	w := &deviceWatcher{cfg: cfg, opts: opts, busy: make(map[string]bool)}
+	for _, id := range cfg.ProfileIDs() {
+		profile, _ := findProfile(cfg, id)
+		policy, err := profile.AutoPolicy()
+		if err != nil {
+			return err
+		}
+		if policy == config.AutoOff {
+			continue
+		}
+		if !profile.Device.IsSet() {
+			logger.Warnf("%s 프로필은 device가 설정되지 않아 자동 동기화 대상에서 제외합니다", id)
+			continue
+		}
+		w.profiles = append(w.profiles, profile)
+		fmt.Printf("• %s: %s (auto: %s)\n", id, profile.Device, policy)
+	}
+	if len(w.profiles) == 0 {
+		return fmt.Errorf("auto가 설정된 장치 프로필이 없습니다")
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = 2 * time.Second
+	}
+	ctx, stop := interruptContext()
+	defer stop()
+
+	fmt.Printf("USB 연결을 기다리는 중... (확인 주기: %v, 종료: Ctrl-C)\n", opts.Interval)
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	attached := make(map[string]bool)
+	started, failing := false, false
+	for {
+		volumes, err := device.Volumes()
+		switch {
+		case err != nil && !started:
+			// 처음부터 조회할 수 없으면 지원하지 않는 환경이므로 종료
+			return err
+		case err != nil:
+			// 일시적인 오류는 연결 상태를 그대로 두고 다음 주기에 다시 시도 (같은 오류를 반복해서 경고하지 않음)
+			if !failing {
+				logger.Warnf("장치 목록 조회 실패, 다음 주기에 다시 시도합니다: %v", err)
+			} else {
+				logger.Debugf("장치 목록 조회 실패: %v", err)
+			}
+			failing = true
+		default:
+			if failing {
+				logger.Infof("장치 목록 조회가 다시 가능해졌습니다")
+			}
+			started, failing = true, false
+
+			current := make(map[string]bool)
+			for _, volume := range volumes {
+				key := volume.Point + "|" + volume.ID()
+				current[key] = true
+				if attached[key] {
+					continue
+				}
+
+				matched := w.matching(volume)
+				if len(matched) == 0 {
+					continue
+				}
+				logger.Infof("장치 연결됨: %s (%s)", volume.Label, volume.Point)
+				w.wg.Add(1)
+				go w.handle(ctx, volume, matched)
+			}
+			attached = current
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("장치 감시를 중단하고 진행 중인 동기화가 정리되기를 기다립니다...")
+			w.wg.Wait()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// matching 볼륨과 일치하는 자동 동기화 프로필
+func (w *deviceWatcher) matching(volume device.Volume) []*config.SyncProfile {
+	matched := []*config.SyncProfile{}
+	for _, profile := range w.profiles {
+		if device.Matches(volume, profile.Device.Label, profile.Device.UUID) {
+			matched = append(matched, profile)
+		}
+	}
+	return matched
+}
+
+// handle 연결된 볼륨에 일치하는 프로필을 차례로 계획하고 적용
+func (w *deviceWatcher) handle(ctx context.Context, volume device.Volume, profiles []*config.SyncProfile) {
+	defer w.wg.Done()
+
+	w.mu.Lock()
+	if w.busy[volume.Point] {
+		w.mu.Unlock()
+		return
+	}
+	w.busy[volume.Point] = true
+	w.mu.Unlock()
+
+	defer func() {
+		w.mu.Lock()
+		delete(w.busy, volume.Point)
+		w.mu.Unlock()
+	}()
+
+	for _, base := range profiles {
+		profile := *base
+		bindTarget(&profile, volume.Point)
+
+		label := fmt.Sprintf("%s@%s", profile.ID, volume.Point)
+		writer := newPrefixWriter(os.Stdout, &w.outputMu, fmt.Sprintf("[%s] ", label))
+		err := w.syncProfile(ctx, &profile, writer)
+		writer.Flush()
+
+		if interrupted(err) {
+			fmt.Printf("⏹️  %s 동기화가 중단되었습니다. 다음에 연결하면 남은 파일을 다시 동기화합니다.\n", label)
+			return
+		}
+		if err != nil {
+			logger.Errorf("자동 동기화 실패: %s: %v", label, err)
+			notify.Send("❌ USB 동기화 실패", fmt.Sprintf("%s: %v", label, err))
+			return
+		}
+	}
+
+	message := fmt.Sprintf("%s (%s) 동기화 완료", volume.Label, volume.Point)
+	fmt.Printf("🟢 %s. USB를 뽑아도 됩니다.\n", message)
+	notify.Send("✅ USB 동기화 완료", message)
+}
+
+// syncProfile 한 프로필을 계획하고 auto 정책에 따라 확인 후 적용
+func (w *deviceWatcher) syncProfile(ctx context.Context, profile *config.SyncProfile, out *prefixWriter) error {
+	engine := sync.NewSyncEngine(w.cfg)
+	engine.SetUseCache(!w.opts.NoCache)
+	engine.SetOutput(out)
+
+	if err := engine.ValidateProfile(profile); err != nil {
+		return fmt.Errorf("프로필 유효성 검사 실패: %w", err)
+	}
+
+	changes, err := planProfile(ctx, w.cfg, engine, profile, out)
+	if err != nil {
+		return err
+	}
+	if !changes.HasChanges && !changes.HasDeletions {
+		fmt.Fprintln(out, "✅ 동기화할 변경사항이 없습니다.")
+		return nil
+	}
+
+	summary := fmt.Sprintf("%s → %s: 복사 %d개, 삭제 %d개",
+		profile.ID, profile.LocalPath, len(changes.Changes), len(changes.Deletions))
+	approved, err := w.approve(ctx, profile, changes, summary)
+	if err != nil {
+		return err
+	}
+	if !approved {
+		fmt.Fprintln(out, "동기화가 취소되었습니다.")
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("확인 중 중단됨: %w", ctx.Err())
+	}
+	return applyPlan(ctx, w.cfg, engine, profile, changes, out)
+}
+
+// approve auto 정책에 따라 동기화 승인 여부 결정
+func (w *deviceWatcher) approve(ctx context.Context, profile *config.SyncProfile, changes *sync.SyncResult, summary string) (bool, error) {
+	policy, _ := profile.AutoPolicy()
+	switch policy {
+	case config.AutoApply:
+		return true, nil
+	case config.AutoDesktop:
+		return notify.Confirm("USB 동기화", summary+"\n동기화하시겠습니까?")
+	default:
+		w.promptMu.Lock()
+		defer w.promptMu.Unlock()
+		showChanges(changes)
+		return askYesNo(ctx, summary+" 동기화하시겠습니까? (y/n): ")
+	}
+}
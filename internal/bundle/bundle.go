@@ -0,0 +1,288 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 번들 안의 메타데이터 파일과 파일 내용 디렉토리
+const (
+	MetadataFile = "bundle.json"
+	FilesDir     = "files"
+)
+
+// 변경 종류
+const (
+	ActionAdded    = "added"
+	ActionModified = "modified"
+	ActionDeleted  = "deleted"
+)
+
+// Change 번들에 담긴 변경 하나 (SHA256은 변경 후, BaseSHA256은 마지막 동기화 시점의 내용)
+type Change struct {
+	Path       string    `json:"path"`
+	Action     string    `json:"action"`
+	Size       int64     `json:"size,omitempty"`
+	ModTime    time.Time `json:"mtime,omitempty"`
+	SHA256     string    `json:"sha256,omitempty"`
+	BaseSHA256 string    `json:"base_sha256,omitempty"`
+}
+
+// Bundle 대상에서 수집한 로컬 변경 묶음의 메타데이터
+type Bundle struct {
+	Profile    string    `json:"profile"`
+	ServerPath string    `json:"server_path"`
+	Target     string    `json:"target"`
+	Host       string    `json:"host"`
+	Created    time.Time `json:"created"`
+	BaseTime   time.Time `json:"base_time"` // 비교 기준인 마지막 동기화 시각
+	Changes    []Change  `json:"changes"`
+}
+
+// Write 메타데이터와 추가/수정된 파일 내용을 tar.gz 번들로 저장 (root는 파일을 읽을 대상 경로)
+func Write(archive string, b *Bundle, root string) (err error) {
+	out, err := os.Create(archive)
+	if err != nil {
+		return fmt.Errorf("번들 파일 생성 실패: %w", err)
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("번들 파일 저장 실패: %w", cerr)
+		}
+		if err != nil {
+			os.Remove(archive)
+		}
+	}()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	// 검토하기 쉽도록 메타데이터를 맨 앞에 기록
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("번들 메타데이터 마샬링 실패: %w", err)
+	}
+	header := &tar.Header{Name: MetadataFile, Mode: 0644, Size: int64(len(data)), ModTime: b.Created}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("번들 기록 실패: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("번들 기록 실패: %w", err)
+	}
+
+	for _, change := range b.Changes {
+		if change.Action == ActionDeleted {
+			continue
+		}
+		if err := addFile(tw, filepath.Join(root, filepath.FromSlash(change.Path)), path.Join(FilesDir, change.Path)); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("번들 기록 실패: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("번들 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// addFile 파일 하나를 tar 항목으로 추가
+func addFile(tw *tar.Writer, source, name string) error {
+	file, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("파일 정보 확인 실패: %w", err)
+	}
+	header := &tar.Header{Name: name, Mode: 0644, Size: info.Size(), ModTime: info.ModTime()}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("번들 기록 실패: %w", err)
+	}
+	if _, err := io.Copy(tw, file); err != nil {
+		return fmt.Errorf("번들 기록 실패: %s: %w", source, err)
+	}
+	return nil
+}
+
+// Read 번들의 메타데이터 읽기 (경로가 대상 밖을 가리키는 번들은 거부)
+func Read(archive string) (*Bundle, error) {
+	var b *Bundle
+	err := walk(archive, func(header *tar.Header, r io.Reader) error {
+		if header.Name != MetadataFile {
+			return nil
+		}
+		b = &Bundle{}
+		if err := json.NewDecoder(r).Decode(b); err != nil {
+			return fmt.Errorf("번들 메타데이터 파싱 실패: %w", err)
+		}
+		return errStop
+	})
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, fmt.Errorf("번들에 %s이(가) 없습니다: %s", MetadataFile, archive)
+	}
+
+	for _, change := range b.Changes {
+		if !ValidPath(change.Path) {
+			return nil, fmt.Errorf("번들에 잘못된 경로가 있습니다: %q", change.Path)
+		}
+	}
+	return b, nil
+}
+
+// Extract 번들에 담긴 파일 중 want에 있는 경로를 dir 아래에 기록 (임시 파일에 쓴 뒤 교체, 수정 시각 유지)
+// want는 경로별 메타데이터의 SHA-256이며, 쓰면서 계산한 해시가 다르면 임시 파일을 지우고 중단
+// ctx가 취소되면 쓰던 임시 파일을 지우고 멈춤. 번들에 내용이 없는 경로가 남으면 오류
+// 중간에 실패해도 이미 교체한 파일은 그대로이므로 교체한 경로 목록을 함께 반환
+func Extract(ctx context.Context, archive, dir string, want map[string]string) ([]string, error) {
+	remaining := make(map[string]string, len(want))
+	for rel, sum := range want {
+		remaining[rel] = sum
+	}
+	written := []string{}
+
+	err := walk(archive, func(header *tar.Header, r io.Reader) error {
+		if ctx.Err() != nil {
+			return fmt.Errorf("번들 적용 중단: %w", ctx.Err())
+		}
+		rel, ok := strings.CutPrefix(header.Name, FilesDir+"/")
+		if !ok || header.Typeflag != tar.TypeReg || !ValidPath(rel) {
+			return nil
+		}
+		sum, wanted := remaining[rel]
+		if !wanted {
+			return nil
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("디렉토리 생성 실패: %w", err)
+		}
+		tmp := target + ".sync-tmp"
+		hash := sha256.New()
+		if err := writeFile(tmp, io.TeeReader(contextReader{ctx: ctx, r: r}, hash), header.ModTime); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+		if got := hex.EncodeToString(hash.Sum(nil)); got != sum {
+			os.Remove(tmp)
+			return fmt.Errorf("번들 파일 내용이 메타데이터와 다릅니다: %s (sha256 %s, 기대값 %s)", rel, got, sum)
+		}
+		if err := os.Rename(tmp, target); err != nil {
+			os.Remove(tmp)
+			return fmt.Errorf("파일 교체 실패: %s: %w", target, err)
+		}
+		delete(remaining, rel)
+		written = append(written, rel)
+		return nil
+	})
+	if err != nil {
+		return written, err
+	}
+
+	if len(remaining) > 0 {
+		missing := make([]string, 0, len(remaining))
+		for rel := range remaining {
+			missing = append(missing, rel)
+		}
+		sort.Strings(missing)
+		return written, fmt.Errorf("번들에 파일 내용이 없습니다 (잘리거나 수정된 번들): %s", strings.Join(missing, ", "))
+	}
+	return written, nil
+}
+
+// ValidPath 번들 경로가 대상 기준 상대 경로인지 확인
+func ValidPath(rel string) bool {
+	if rel == "" || path.IsAbs(rel) || strings.Contains(rel, `\`) {
+		return false
+	}
+	clean := path.Clean(rel)
+	return clean == rel && clean != "." && clean != ".." && !strings.HasPrefix(clean, "../")
+}
+
+// errStop walk 순회를 정상 종료
+var errStop = errors.New("stop")
+
+// walk tar.gz 번들의 항목을 순서대로 전달
+func walk(archive string, fn func(header *tar.Header, r io.Reader) error) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return fmt.Errorf("번들 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("번들 형식 오류: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("번들 읽기 실패: %w", err)
+		}
+		if err := fn(header, tr); err == errStop {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// writeFile 내용을 파일로 쓰고 수정 시각 적용
+func writeFile(target string, r io.Reader, modTime time.Time) error {
+	out, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("파일 생성 실패: %w", err)
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return fmt.Errorf("파일 쓰기 실패: %s: %w", target, err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("파일 쓰기 실패: %s: %w", target, err)
+	}
+	if err := os.Chtimes(target, modTime, modTime); err != nil {
+		return fmt.Errorf("수정 시각 설정 실패: %w", err)
+	}
+	return nil
+}
+
+// contextReader ctx가 취소되면 읽기를 멈추는 Reader
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,30 @@
+package bundle
+
+import "testing"
+
+func TestValidPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"a.txt", true},
+		{"a/b/c.txt", true},
+		{"..a", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"/a", false},
+		{"../a", false},
+		{"a/../b", false},
+		{"a/./b", false},
+		{"a//b", false},
+		{"a/", false},
+		{`a\b`, false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidPath(tt.path); got != tt.want {
+			t.Errorf("ValidPath(%q) = %v, 기대값 %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import "path/filepath"
+
+// 백업 설정 기본값
+const (
+	DefaultBackupDir      = ".sync-backup" // 대상 경로 기준 숨김 디렉토리
+	DefaultBackupKeep     = 5              // keep을 지정하지 않았을 때 보관할 회차 수
+	DefaultBackupWarnSize = "1G"           // 대상에 보관한 백업이 이 크기를 넘으면 경고
+)
+
+// BackupConfig 덮어쓰거나 삭제한 파일의 이전 버전 보관 설정
+type BackupConfig struct {
+	Enabled  bool   `yaml:"enabled,omitempty" mapstructure:"enabled"`
+	Dir      string `yaml:"dir,omitempty" mapstructure:"dir"`             // 상대 경로면 대상(USB)에, 절대 경로면 로컬에 보관
+	Keep     int    `yaml:"keep,omitempty" mapstructure:"keep"`           // 보관할 백업 회차 수 (0이면 기본값 5, -1이면 제한 없음)
+	MaxAge   string `yaml:"max_age,omitempty" mapstructure:"max_age"`     // 이 기간보다 오래된 백업 삭제 (비우면 제한 없음)
+	WarnSize string `yaml:"warn_size,omitempty" mapstructure:"warn_size"` // 대상에 보관한 백업이 이 크기를 넘으면 경고 (기본값 1G)
+}
+
+// OnTarget 백업을 대상 경로 아래에 보관하는지 여부
+func (b BackupConfig) OnTarget() bool {
+	return !filepath.IsAbs(b.Dir)
+}
+
+// GetBackup 기본값을 적용한 프로필 백업 설정
+func (p *SyncProfile) GetBackup() BackupConfig {
+	backup := p.Backup
+	if backup.Dir == "" {
+		backup.Dir = DefaultBackupDir
+	}
+	if backup.Keep == 0 {
+		backup.Keep = DefaultBackupKeep
+	}
+	if backup.WarnSize == "" {
+		backup.WarnSize = DefaultBackupWarnSize
+	}
+	return backup
+}
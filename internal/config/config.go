@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -13,6 +15,7 @@ type Config struct {
 	Server   ServerConfig           `yaml:"server"`
 	Sync     SyncConfig             `yaml:"sync"`
 	Profiles map[string]SyncProfile `yaml:"profiles"`
+	Groups   map[string][]string    `yaml:"groups,omitempty" mapstructure:"groups"`
 	Logging  LoggingConfig          `yaml:"logging"`
 	UI       UIConfig               `yaml:"ui"`
 }
@@ -27,19 +30,108 @@ type ServerConfig struct {
 
 // SyncConfig 동기화 기본 설정
 type SyncConfig struct {
-	Options         []string `yaml:"options" mapstructure:"options"`
-	DefaultExcludes []string `yaml:"default_excludes" mapstructure:"default_excludes"`
+	Options         []string       `yaml:"options" mapstructure:"options"`
+	DefaultExcludes []string       `yaml:"default_excludes" mapstructure:"default_excludes"`
+	StateDir        string         `yaml:"state_dir,omitempty" mapstructure:"state_dir"`
+	Cache           CacheConfig    `yaml:"cache" mapstructure:"cache"`
+	Transfer        TransferConfig `yaml:"transfer,omitempty" mapstructure:"transfer"`
+}
+
+// CacheConfig 로컬 fetch 캐시 설정
+type CacheConfig struct {
+	Dir     string `yaml:"dir,omitempty" mapstructure:"dir"`
+	MaxAge  string `yaml:"max_age,omitempty" mapstructure:"max_age"`
+	MaxSize string `yaml:"max_size,omitempty" mapstructure:"max_size"`
 }
 
 // SyncProfile 동기화 프로필
 type SyncProfile struct {
-	Name        string   `yaml:"name" mapstructure:"name"`
-	Description string   `yaml:"description" mapstructure:"description"`
-	ServerPath  string   `yaml:"server_path" mapstructure:"server_path"`
-	LocalPath   string   `yaml:"local_path" mapstructure:"local_path"`
-	Options     []string `yaml:"options,omitempty" mapstructure:"options"`
-	Includes    []string `yaml:"includes,omitempty" mapstructure:"includes"`
-	Excludes    []string `yaml:"excludes,omitempty" mapstructure:"excludes"`
+	ID          string         `yaml:"-" mapstructure:"-"`
+	Name        string         `yaml:"name" mapstructure:"name"`
+	Description string         `yaml:"description" mapstructure:"description"`
+	ServerPath  string         `yaml:"server_path" mapstructure:"server_path"`
+	LocalPath   string         `yaml:"local_path" mapstructure:"local_path"`
+	Device      DeviceMatch    `yaml:"device,omitempty" mapstructure:"device"`
+	MountPoint  string         `yaml:"-" mapstructure:"-"`
+	Options     []string       `yaml:"options,omitempty" mapstructure:"options"`
+	Filters     []string       `yaml:"filters,omitempty" mapstructure:"filters"`
+	Includes    []string       `yaml:"includes,omitempty" mapstructure:"includes"`
+	Excludes    []string       `yaml:"excludes,omitempty" mapstructure:"excludes"`
+	Hooks       HooksConfig    `yaml:"hooks,omitempty" mapstructure:"hooks"`
+	Eject       bool           `yaml:"eject,omitempty" mapstructure:"eject"`
+	Staging     bool           `yaml:"staging,omitempty" mapstructure:"staging"`
+	Backup      BackupConfig   `yaml:"backup,omitempty" mapstructure:"backup"`
+	Auto        string         `yaml:"auto,omitempty" mapstructure:"auto"`
+	Schedule    ScheduleConfig `yaml:"schedule,omitempty" mapstructure:"schedule"`
+	Transfer    TransferConfig `yaml:"transfer,omitempty" mapstructure:"transfer"`
+}
+
+// 장치 연결 시 자동 동기화 정책 (watch-devices)
+const (
+	AutoOff     = "off"     // 자동 동기화 안 함
+	AutoConfirm = "confirm" // 터미널에서 확인 후 적용
+	AutoDesktop = "desktop" // 데스크톱 대화상자로 확인 후 적용
+	AutoApply   = "apply"   // 확인 없이 적용
+)
+
+// AutoPolicy 자동 동기화 정책 반환 (비어 있으면 off)
+func (p *SyncProfile) AutoPolicy() (string, error) {
+	switch p.Auto {
+	case "", AutoOff:
+		return AutoOff, nil
+	case AutoConfirm, AutoDesktop, AutoApply:
+		return p.Auto, nil
+	default:
+		return "", fmt.Errorf("%s 프로필의 auto 값이 잘못되었습니다: %s (off, confirm, desktop, apply)", p.ID, p.Auto)
+	}
+}
+
+// DeviceMatch 대상 USB 식별 조건 (설정 시 local_path는 볼륨 루트 기준 상대 경로)
+type DeviceMatch struct {
+	Label string `yaml:"label,omitempty" mapstructure:"label"`
+	UUID  string `yaml:"uuid,omitempty" mapstructure:"uuid"`
+}
+
+// IsSet 장치 식별 조건이 설정되었는지 확인
+func (d DeviceMatch) IsSet() bool {
+	return d.Label != "" || d.UUID != ""
+}
+
+// String 장치 식별 조건 표시 문자열
+func (d DeviceMatch) String() string {
+	parts := []string{}
+	if d.Label != "" {
+		parts = append(parts, "label="+d.Label)
+	}
+	if d.UUID != "" {
+		parts = append(parts, "uuid="+d.UUID)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ScheduleConfig 프로필 예약 실행 설정 (daemon)
+type ScheduleConfig struct {
+	Cron    string        `yaml:"cron,omitempty" mapstructure:"cron"`
+	Windows []string      `yaml:"windows,omitempty" mapstructure:"windows"`
+	BWLimit BWLimitConfig `yaml:"bwlimit,omitempty" mapstructure:"bwlimit"`
+}
+
+// BWLimitConfig 주간/야간 대역폭 제한 (rsync --bwlimit 값, 비우면 제한 없음)
+type BWLimitConfig struct {
+	Day        string `yaml:"day,omitempty" mapstructure:"day"`
+	Night      string `yaml:"night,omitempty" mapstructure:"night"`
+	NightHours string `yaml:"night_hours,omitempty" mapstructure:"night_hours"`
+}
+
+// DefaultNightHours 야간 대역폭 제한을 적용하는 기본 시간대
+const DefaultNightHours = "19:00-07:00"
+
+// HooksConfig 프로필 훅 명령어 (셸로 실행, SYNC_* 환경 변수 전달)
+type HooksConfig struct {
+	PrePlan  string `yaml:"pre_plan,omitempty" mapstructure:"pre_plan"`
+	PreSync  string `yaml:"pre_sync,omitempty" mapstructure:"pre_sync"`
+	PostSync string `yaml:"post_sync,omitempty" mapstructure:"post_sync"`
+	OnError  string `yaml:"on_error,omitempty" mapstructure:"on_error"`
 }
 
 // LoggingConfig 로깅 설정
@@ -86,32 +178,38 @@ func InitializeConfig(configPath string) error {
 				".Trash-1000",
 				".Trash-1000/*",
 			},
+			Cache: CacheConfig{
+				MaxAge:  "72h",
+				MaxSize: "100GB",
+			},
 		},
 		Profiles: map[string]SyncProfile{
 			"aunes_ins": {
 				Name:        "AUNES_INS",
 				Description: "AUNES INS 폴더 동기화",
 				ServerPath:  "/stor2/USB_SYNC/AUNES_INS",
-				LocalPath:   "/Volumes/AUNES_INS",
+				Device:      DeviceMatch{Label: "AUNES_INS"},
 				Excludes:    []string{},
 			},
 			"ventoy": {
 				Name:        "Ventoy (KICKSTART, config)",
 				Description: "Ventoy 폴더 동기화 (ISO 파일 제외)",
 				ServerPath:  "/stor2/USB_SYNC/Ventoy",
-				LocalPath:   "/Volumes/Ventoy",
+				Device:      DeviceMatch{Label: "Ventoy"},
 				Excludes:    []string{"_iso/*.iso"},
 			},
 			"iso_only": {
 				Name:        "ISO Files Only",
 				Description: "ISO 파일만 동기화",
 				ServerPath:  "/stor2/USB_SYNC/Ventoy",
-				LocalPath:   "/Volumes/Ventoy",
-				Includes:    []string{"*.iso"},
-				Excludes:    []string{"*"},
-				Options:     []string{"-r", "-z", "--itemize-changes"},
+				Device:      DeviceMatch{Label: "Ventoy"},
+				Filters:     []string{"+ */", "+ *.iso", "- *"},
+				Options:     []string{"-r", "-z", "-m", "--itemize-changes"},
 			},
 		},
+		Groups: map[string][]string{
+			"field_kit": {"aunes_ins", "ventoy"},
+		},
 		Logging: LoggingConfig{
 			Level:  "info",
 			Format: "text",
@@ -144,6 +242,76 @@ func InitializeConfig(configPath string) error {
 	return nil
 }
 
+// Normalize 설정 로딩 후 파생 값 채우기 (프로필 ID, 상태/캐시 디렉토리 기본값)
+func (c *Config) Normalize() {
+	for id, profile := range c.Profiles {
+		profile.ID = id
+		c.Profiles[id] = profile
+	}
+
+	if c.Sync.StateDir == "" {
+		c.Sync.StateDir = defaultStateDir()
+	}
+	c.Sync.StateDir = ExpandHome(c.Sync.StateDir)
+
+	if c.Sync.Cache.Dir == "" {
+		c.Sync.Cache.Dir = filepath.Join(c.Sync.StateDir, "cache")
+	}
+	c.Sync.Cache.Dir = ExpandHome(c.Sync.Cache.Dir)
+}
+
+// GroupProfiles 그룹에 속한 프로필 ID 목록 반환
+func (c *Config) GroupProfiles(group string) ([]string, error) {
+	members, exists := c.Groups[group]
+	if !exists {
+		return nil, fmt.Errorf("프로필 그룹을 찾을 수 없습니다: %s", group)
+	}
+	// 같은 프로필이 여러 번 있어도 한 번만 포함
+	ids := make([]string, 0, len(members))
+	seen := make(map[string]bool)
+	for _, id := range members {
+		if _, ok := c.Profiles[id]; !ok {
+			return nil, fmt.Errorf("그룹 %s에 정의되지 않은 프로필이 있습니다: %s", group, id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// ProfileIDs 정렬된 전체 프로필 ID 목록 반환
+func (c *Config) ProfileIDs() []string {
+	ids := make([]string, 0, len(c.Profiles))
+	for id := range c.Profiles {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// defaultStateDir 기본 상태 디렉토리 (~/.sync-tool)
+func defaultStateDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".sync-tool"
+	}
+	return filepath.Join(home, ".sync-tool")
+}
+
+// ExpandHome 경로 앞의 ~를 홈 디렉토리로 치환
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
 // GetSyncOptions 프로필의 동기화 옵션 반환
 func (p *SyncProfile) GetSyncOptions(baseOptions []string) []string {
 	if len(p.Options) > 0 {
@@ -0,0 +1,94 @@
+package config
+
+// 전송 설정 기본값
+const (
+	DefaultTransferTimeout = "5m"
+	DefaultPartialDir      = ".rsync-partial"
+	DefaultRetryAttempts   = 3
+	DefaultRetryBackoff    = "10s"
+	DefaultRetryMaxBackoff = "5m"
+)
+
+// DefaultRetryExitCodes 재시도할 rsync 종료 코드 (소켓/프로토콜/부분 전송/타임아웃/연결 오류)
+var DefaultRetryExitCodes = []int{10, 12, 23, 24, 30, 35, 255}
+
+// TransferConfig rsync 전송 설정 (sync.transfer 전역 값에 프로필 값이 우선)
+type TransferConfig struct {
+	BWLimit       string      `yaml:"bwlimit,omitempty" mapstructure:"bwlimit"`               // --bwlimit 값 (예: "2M")
+	Timeout       string      `yaml:"timeout,omitempty" mapstructure:"timeout"`               // I/O 타임아웃 (기본값 5m)
+	ConTimeout    string      `yaml:"contimeout,omitempty" mapstructure:"contimeout"`         // SSH 연결 타임아웃
+	Nice          *int        `yaml:"nice,omitempty" mapstructure:"nice"`                     // CPU 우선순위 (nice 레벨, 0이면 조정 안 함)
+	IONice        string      `yaml:"ionice,omitempty" mapstructure:"ionice"`                 // I/O 우선순위 (idle, best-effort:N, realtime:N)
+	CompressLevel *int        `yaml:"compress_level,omitempty" mapstructure:"compress_level"` // 압축 수준 (0이면 압축 안 함)
+	PartialDir    string      `yaml:"partial_dir,omitempty" mapstructure:"partial_dir"`       // 부분 전송 파일 디렉토리
+	Retry         RetryConfig `yaml:"retry,omitempty" mapstructure:"retry"`
+}
+
+// RetryConfig 일시적인 rsync 실패 재시도 설정
+type RetryConfig struct {
+	MaxAttempts int    `yaml:"max_attempts,omitempty" mapstructure:"max_attempts"` // 최대 시도 횟수 (1이면 재시도 안 함)
+	Backoff     string `yaml:"backoff,omitempty" mapstructure:"backoff"`           // 첫 재시도 대기 시간 (이후 두 배씩 증가)
+	MaxBackoff  string `yaml:"max_backoff,omitempty" mapstructure:"max_backoff"`   // 최대 대기 시간
+	ExitCodes   []int  `yaml:"exit_codes,omitempty" mapstructure:"exit_codes"`     // 재시도할 종료 코드
+}
+
+// Merge override에 설정된 값으로 덮어쓴 재시도 설정 반환
+func (r RetryConfig) Merge(override RetryConfig) RetryConfig {
+	merged := r
+	if override.MaxAttempts != 0 {
+		merged.MaxAttempts = override.MaxAttempts
+	}
+	if override.Backoff != "" {
+		merged.Backoff = override.Backoff
+	}
+	if override.MaxBackoff != "" {
+		merged.MaxBackoff = override.MaxBackoff
+	}
+	if len(override.ExitCodes) > 0 {
+		merged.ExitCodes = override.ExitCodes
+	}
+	return merged
+}
+
+// Merge override에 설정된 값으로 덮어쓴 전송 설정 반환
+func (t TransferConfig) Merge(override TransferConfig) TransferConfig {
+	merged := t
+	if override.BWLimit != "" {
+		merged.BWLimit = override.BWLimit
+	}
+	if override.Timeout != "" {
+		merged.Timeout = override.Timeout
+	}
+	if override.ConTimeout != "" {
+		merged.ConTimeout = override.ConTimeout
+	}
+	if override.Nice != nil {
+		merged.Nice = override.Nice
+	}
+	if override.IONice != "" {
+		merged.IONice = override.IONice
+	}
+	if override.CompressLevel != nil {
+		merged.CompressLevel = override.CompressLevel
+	}
+	if override.PartialDir != "" {
+		merged.PartialDir = override.PartialDir
+	}
+	merged.Retry = merged.Retry.Merge(override.Retry)
+	return merged
+}
+
+// GetTransfer 프로필에 적용할 전송 설정 (기본값 포함)
+func (p *SyncProfile) GetTransfer(global TransferConfig) TransferConfig {
+	transfer := TransferConfig{
+		Timeout:    DefaultTransferTimeout,
+		PartialDir: DefaultPartialDir,
+		Retry: RetryConfig{
+			MaxAttempts: DefaultRetryAttempts,
+			Backoff:     DefaultRetryBackoff,
+			MaxBackoff:  DefaultRetryMaxBackoff,
+			ExitCodes:   DefaultRetryExitCodes,
+		},
+	}
+	return transfer.Merge(global).Merge(p.Transfer)
+}
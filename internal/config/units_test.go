@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"1024", 1024, false},
+		{"10B", 10, false},
+		{"512M", 512 << 20, false},
+		{"512mb", 512 << 20, false},
+		{"100GB", 100 << 30, false},
+		{"1.5k", 1536, false},
+		{" 2 T ", 2 << 40, false},
+		{"abc", 0, true},
+		{"-1", 0, true},
+		{"5X", 0, true},
+		{"MB", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSize(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSize(%q) 오류 = %v, 오류 기대 = %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, 기대값 %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"1.5d", 36 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{" 2h30m ", 150 * time.Minute, false},
+		{"xd", 0, true},
+		{"-1d", 0, true},
+		{"abc", 0, true},
+		{"7", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDuration(%q) 오류 = %v, 오류 기대 = %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, 기대값 %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package device
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Mount 마운트된 파일시스템 정보
+type Mount struct {
+	Point   string // 마운트 경로
+	Device  string // 장치 경로 (예: /dev/sdb1)
+	FSType  string // 파일시스템 종류
+	Options string // 마운트 옵션
+	DevNum  string // 장치 번호 (major:minor, Linux)
+}
+
+// Volume 식별 정보가 포함된 마운트된 볼륨
+type Volume struct {
+	Mount
+	Label     string
+	UUID      string
+	Serial    string
+	Removable bool
+}
+
+// Handle 경로 아래에 열려 있는 파일 핸들
+type Handle struct {
+	PID     int
+	Command string
+	Path    string
+}
+
+// FindMount 경로가 속한 마운트 반환 (가장 긴 마운트 경로 기준)
+func FindMount(path string) (*Mount, error) {
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mounts, err := Mounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Mount
+	for i := range mounts {
+		if !isUnder(resolved, mounts[i].Point) {
+			continue
+		}
+		if found == nil || len(mounts[i].Point) > len(found.Point) {
+			found = &mounts[i]
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("마운트 정보를 찾을 수 없습니다: %s", path)
+	}
+	return found, nil
+}
+
+// FindVolume 경로가 속한 볼륨과 식별 정보 반환
+func FindVolume(path string) (*Volume, error) {
+	mount, err := FindMount(path)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := Volumes()
+	if err != nil {
+		return nil, err
+	}
+	for i := range volumes {
+		if volumes[i].Point == mount.Point {
+			return &volumes[i], nil
+		}
+	}
+	return &Volume{Mount: *mount}, nil
+}
+
+// ID 볼륨을 구분하는 식별자 (UUID, 시리얼, 라벨 순으로 사용)
+func (v Volume) ID() string {
+	switch {
+	case v.UUID != "":
+		return v.UUID
+	case v.Serial != "":
+		return v.Serial
+	case v.Label != "":
+		return "label:" + v.Label
+	default:
+		return ""
+	}
+}
+
+// Resolve 라벨 또는 UUID로 현재 마운트된 볼륨 하나 찾기
+func Resolve(label, uuid string) (*Volume, error) {
+	volumes, err := Volumes()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := []Volume{}
+	for _, volume := range volumes {
+		if Matches(volume, label, uuid) {
+			matched = append(matched, volume)
+		}
+	}
+
+	condition := describe(label, uuid)
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("장치를 찾을 수 없습니다 (%s). USB가 연결되어 마운트되었는지 확인하세요", condition)
+	case 1:
+		return &matched[0], nil
+	default:
+		points := make([]string, 0, len(matched))
+		for _, volume := range matched {
+			points = append(points, volume.Point)
+		}
+		return nil, fmt.Errorf("여러 장치가 일치합니다 (%s): %s. uuid로 지정하거나 --targets를 사용하세요",
+			condition, strings.Join(points, ", "))
+	}
+}
+
+// Matches 볼륨이 라벨/UUID 조건에 맞는지 확인 (라벨은 글롭 패턴 허용, 대소문자 무시)
+func Matches(volume Volume, label, uuid string) bool {
+	if label == "" && uuid == "" {
+		return false
+	}
+	if uuid != "" && !strings.EqualFold(volume.UUID, uuid) {
+		return false
+	}
+	if label != "" {
+		matched, err := filepath.Match(strings.ToLower(label), strings.ToLower(volume.Label))
+		if err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// describe 조건 설명 문자열
+func describe(label, uuid string) string {
+	parts := []string{}
+	if label != "" {
+		parts = append(parts, "label="+label)
+	}
+	if uuid != "" {
+		parts = append(parts, "uuid="+uuid)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// resolvePath 절대 경로로 바꾸고 심볼릭 링크 해석
+func resolvePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("경로 해석 실패: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", fmt.Errorf("경로 해석 실패: %w", err)
+	}
+	return resolved, nil
+}
+
+// isUnder path가 root와 같거나 그 하위인지 확인
+func isUnder(path, root string) bool {
+	if root == "/" {
+		return strings.HasPrefix(path, "/")
+	}
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
@@ -0,0 +1,129 @@
+package device
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Mounts mount 명령어 출력에서 마운트 목록 읽기
+// 형식: /dev/disk2s1 on /Volumes/Ventoy (exfat, local, nodev, nosuid)
+func Mounts() ([]Mount, error) {
+	output, err := exec.Command("mount").Output()
+	if err != nil {
+		return nil, fmt.Errorf("마운트 테이블 읽기 실패: %w", err)
+	}
+
+	mounts := []Mount{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		on := strings.Index(line, " on ")
+		paren := strings.LastIndex(line, " (")
+		if on < 0 || paren < on {
+			continue
+		}
+
+		options := strings.Split(strings.TrimSuffix(line[paren+2:], ")"), ", ")
+		mounts = append(mounts, Mount{
+			Device:  line[:on],
+			Point:   line[on+4 : paren],
+			FSType:  options[0],
+			Options: strings.Join(options[1:], ","),
+		})
+	}
+	return mounts, nil
+}
+
+// Volumes /Volumes 아래 마운트된 볼륨 목록과 diskutil 정보 조회
+func Volumes() ([]Volume, error) {
+	mounts, err := Mounts()
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := []Volume{}
+	for _, mount := range mounts {
+		if !strings.HasPrefix(mount.Point, "/Volumes/") {
+			continue
+		}
+
+		info := diskutilInfo(mount.Point)
+		volume := Volume{Mount: mount}
+		volume.Label = info["Volume Name"]
+		if volume.Label == "" {
+			volume.Label = filepath.Base(mount.Point)
+		}
+		volume.UUID = info["Volume UUID"]
+		volume.Serial = info["Disk / Partition UUID"]
+		volume.Removable = info["Removable Media"] == "Removable" ||
+			info["Protocol"] == "USB" || info["Device Location"] == "External"
+
+		volumes = append(volumes, volume)
+	}
+	return volumes, nil
+}
+
+// diskutilInfo "diskutil info" 출력의 "키: 값" 줄 파싱
+func diskutilInfo(point string) map[string]string {
+	info := make(map[string]string)
+	output, err := exec.Command("diskutil", "info", point).Output()
+	if err != nil {
+		return info
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			info[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return info
+}
+
+// Flush 파일시스템 버퍼를 디스크에 기록
+func Flush(path string) error {
+	syscall.Sync()
+	return nil
+}
+
+// OpenHandles lsof로 경로 아래에 열린 파일 찾기
+func OpenHandles(path string) ([]Handle, error) {
+	root, err := resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// lsof는 열린 파일이 없으면 종료 코드 1을 반환하므로 출력만 사용
+	output, _ := exec.Command("lsof", "-Fpcn", "+D", root).Output()
+
+	handles := []Handle{}
+	var pid int
+	var command string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			pid, _ = strconv.Atoi(line[1:])
+		case 'c':
+			command = line[1:]
+		case 'n':
+			handles = append(handles, Handle{PID: pid, Command: command, Path: line[1:]})
+		}
+	}
+	return handles, nil
+}
+
+// Unmount diskutil로 볼륨 추출
+func Unmount(mount *Mount) error {
+	output, err := exec.Command("diskutil", "eject", mount.Point).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("마운트 해제 실패: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package device
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// Mounts /proc/self/mountinfo에서 마운트 목록 읽기
+func Mounts() ([]Mount, error) {
+	file, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, fmt.Errorf("마운트 테이블 읽기 실패: %w", err)
+	}
+	defer file.Close()
+
+	mounts := []Mount{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// 형식: id parent major:minor root mountpoint options [optional...] - fstype source superoptions
+		fields := strings.Fields(scanner.Text())
+		sep := -1
+		for i, field := range fields {
+			if field == "-" {
+				sep = i
+				break
+			}
+		}
+		if len(fields) < 6 || sep < 0 || sep+2 >= len(fields) {
+			continue
+		}
+
+		mounts = append(mounts, Mount{
+			DevNum:  fields[2],
+			Point:   unescapeMount(fields[4]),
+			Options: fields[5],
+			FSType:  fields[sep+1],
+			Device:  unescapeMount(fields[sep+2]),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("마운트 테이블 읽기 실패: %w", err)
+	}
+	return mounts, nil
+}
+
+// Volumes 블록 장치에 마운트된 볼륨 목록과 라벨/UUID/시리얼 조회
+// udev 데이터베이스(/run/udev/data)를 우선 사용하고, 없으면 /dev/disk/by-label, by-uuid를 사용
+func Volumes() ([]Volume, error) {
+	mounts, err := Mounts()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := reverseLinks("/dev/disk/by-label")
+	uuids := reverseLinks("/dev/disk/by-uuid")
+
+	volumes := []Volume{}
+	for _, mount := range mounts {
+		if !strings.HasPrefix(mount.Device, "/dev/") {
+			continue
+		}
+
+		volume := Volume{Mount: mount}
+		props := udevProperties(mount.DevNum)
+		volume.Label = props["ID_FS_LABEL"]
+		volume.UUID = props["ID_FS_UUID"]
+		volume.Serial = props["ID_SERIAL_SHORT"]
+
+		device, err := filepath.EvalSymlinks(mount.Device)
+		if err != nil {
+			device = mount.Device
+		}
+		if volume.Label == "" {
+			volume.Label = labels[device]
+		}
+		if volume.UUID == "" {
+			volume.UUID = uuids[device]
+		}
+		volume.Removable = props["ID_BUS"] == "usb" || isRemovable(mount.DevNum)
+
+		volumes = append(volumes, volume)
+	}
+	return volumes, nil
+}
+
+// udevProperties udev 데이터베이스에서 장치 속성 읽기 (E:KEY=VALUE 줄)
+func udevProperties(devNum string) map[string]string {
+	props := make(map[string]string)
+	if devNum == "" {
+		return props
+	}
+
+	data, err := os.ReadFile(filepath.Join("/run/udev/data", "b"+devNum))
+	if err != nil {
+		return props
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "E:") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line[2:], "="); ok {
+			props[key] = value
+		}
+	}
+	return props
+}
+
+// reverseLinks /dev/disk/by-* 디렉토리의 장치 → 이름 맵 생성
+func reverseLinks(dir string) map[string]string {
+	links := make(map[string]string)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return links
+	}
+	for _, entry := range entries {
+		target, err := filepath.EvalSymlinks(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		links[target] = unescapeUdev(entry.Name())
+	}
+	return links
+}
+
+// isRemovable sysfs의 removable 플래그 확인 (파티션이면 상위 디스크 기준)
+func isRemovable(devNum string) bool {
+	if devNum == "" {
+		return false
+	}
+	sysPath, err := filepath.EvalSymlinks(filepath.Join("/sys/dev/block", devNum))
+	if err != nil {
+		return false
+	}
+	for _, candidate := range []string{sysPath, filepath.Dir(sysPath)} {
+		if data, err := os.ReadFile(filepath.Join(candidate, "removable")); err == nil {
+			return strings.TrimSpace(string(data)) == "1"
+		}
+	}
+	return false
+}
+
+// unescapeUdev /dev/disk/by-label 이름의 \x20 같은 이스케이프 해제
+func unescapeUdev(value string) string {
+	if !strings.Contains(value, "\\x") {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+3 < len(value) && value[i+1] == 'x' {
+			if n, err := strconv.ParseUint(value[i+2:i+4], 16, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// Flush 파일시스템 버퍼를 디스크에 기록
+func Flush(path string) error {
+	// 대상 파일시스템만 동기화 (syncfs), 실패하면 전체 sync
+	dir, err := os.Open(path)
+	if err == nil {
+		defer dir.Close()
+		if err := unix.Syncfs(int(dir.Fd())); err == nil {
+			return nil
+		}
+	}
+	unix.Sync()
+	return nil
+}
+
+// OpenHandles /proc에서 경로 아래에 열린 파일과 작업 디렉토리 찾기
+func OpenHandles(path string) ([]Handle, error) {
+	root, err := resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("프로세스 목록 읽기 실패: %w", err)
+	}
+
+	self := os.Getpid()
+	handles := []Handle{}
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil || pid == self {
+			continue
+		}
+		procDir := filepath.Join("/proc", proc.Name())
+		command := readComm(procDir)
+
+		if cwd, err := os.Readlink(filepath.Join(procDir, "cwd")); err == nil && isUnder(cwd, root) {
+			handles = append(handles, Handle{PID: pid, Command: command, Path: cwd})
+		}
+
+		fds, err := os.ReadDir(filepath.Join(procDir, "fd"))
+		if err != nil {
+			// 다른 사용자의 프로세스는 권한이 없어 확인할 수 없음
+			continue
+		}
+		for _, fd := range fds {
+			target, err := os.Readlink(filepath.Join(procDir, "fd", fd.Name()))
+			if err == nil && isUnder(target, root) {
+				handles = append(handles, Handle{PID: pid, Command: command, Path: target})
+			}
+		}
+	}
+	return handles, nil
+}
+
+// Unmount 볼륨 마운트 해제 (udisksctl이 있으면 사용해 일반 사용자도 해제 가능)
+func Unmount(mount *Mount) error {
+	if _, err := exec.LookPath("udisksctl"); err == nil && strings.HasPrefix(mount.Device, "/dev/") {
+		output, err := exec.Command("udisksctl", "unmount", "-b", mount.Device).CombinedOutput()
+		if err == nil {
+			// 전원 차단은 실패해도 마운트 해제는 완료된 상태
+			_ = exec.Command("udisksctl", "power-off", "-b", mount.Device).Run()
+			return nil
+		}
+		return fmt.Errorf("마운트 해제 실패: %s", strings.TrimSpace(string(output)))
+	}
+
+	output, err := exec.Command("umount", mount.Point).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("마운트 해제 실패: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// readComm 프로세스 이름 읽기
+func readComm(procDir string) string {
+	data, err := os.ReadFile(filepath.Join(procDir, "comm"))
+	if err != nil {
+		return "?"
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// unescapeMount mountinfo의 8진수 이스케이프(\040 등) 해제
+func unescapeMount(value string) string {
+	if !strings.Contains(value, "\\") {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+3 < len(value) {
+			if n, err := strconv.ParseUint(value[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
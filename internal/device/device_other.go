@@ -0,0 +1,38 @@
+//go:build !linux && !darwin
+
+package device
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Mounts 지원하지 않는 플랫폼
+func Mounts() ([]Mount, error) {
+	return nil, fmt.Errorf("마운트 테이블 조회를 지원하지 않는 플랫폼입니다: %s", runtime.GOOS)
+}
+
+// Volumes 지원하지 않는 플랫폼
+func Volumes() ([]Volume, error) {
+	return nil, fmt.Errorf("장치 조회를 지원하지 않는 플랫폼입니다: %s", runtime.GOOS)
+}
+
+// Usage 지원하지 않는 플랫폼
+func Usage(path string) (total, free uint64, err error) {
+	return 0, 0, fmt.Errorf("용량 조회를 지원하지 않는 플랫폼입니다: %s", runtime.GOOS)
+}
+
+// Flush 지원하지 않는 플랫폼 (아무것도 하지 않음)
+func Flush(path string) error {
+	return nil
+}
+
+// OpenHandles 지원하지 않는 플랫폼
+func OpenHandles(path string) ([]Handle, error) {
+	return nil, fmt.Errorf("열린 파일 확인을 지원하지 않는 플랫폼입니다: %s", runtime.GOOS)
+}
+
+// Unmount 지원하지 않는 플랫폼
+func Unmount(mount *Mount) error {
+	return fmt.Errorf("마운트 해제를 지원하지 않는 플랫폼입니다: %s (운영체제의 '안전하게 제거'를 사용하세요)", runtime.GOOS)
+}
@@ -0,0 +1,18 @@
+//go:build linux || darwin
+
+package device
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// Usage 볼륨 전체 용량과 사용 가능한 공간 (바이트)
+func Usage(path string) (total, free uint64, err error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, 0, fmt.Errorf("용량 조회 실패: %w", err)
+	}
+	return stat.Blocks * uint64(stat.Bsize), stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,214 @@
+package export
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf8"
+
+	"sync-tool/internal/manifest"
+)
+
+// ManifestFile 내보낸 파일 목록과 해시를 기록하는 파일 이름 (아카이브/이미지 루트)
+const ManifestFile = "sync-manifest.json"
+
+// 내보내기 형식
+const (
+	FormatTar = "tar"
+	FormatZip = "zip"
+	FormatISO = "iso"
+)
+
+// Formats 지원하는 내보내기 형식 목록
+var Formats = []string{FormatTar, FormatZip, FormatISO}
+
+// Write 루트 아래 매니페스트의 파일들과 매니페스트 자체를 지정한 형식으로 저장
+// tar는 출력 파일이 .gz/.tgz로 끝나면 gzip으로 압축하고, iso는 외부 도구로 루트 디렉토리 전체를 이미지로 만듦
+func Write(format, output, root string, m *manifest.Manifest, label string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("매니페스트 마샬링 실패: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ManifestFile), data, 0644); err != nil {
+		return fmt.Errorf("매니페스트 저장 실패: %w", err)
+	}
+
+	files := make([]string, 0, len(m.Entries)+1)
+	for _, entry := range m.Entries {
+		files = append(files, entry.Path)
+	}
+	files = append(files, ManifestFile)
+
+	switch format {
+	case FormatTar:
+		return writeArchive(output, func(w io.Writer) error { return writeTar(w, root, files, compressed(output)) })
+	case FormatZip:
+		return writeArchive(output, func(w io.Writer) error { return writeZip(w, root, files) })
+	case FormatISO:
+		return writeISO(output, root, label)
+	}
+	return Check(format)
+}
+
+// compressed 출력 파일 이름으로 gzip 압축 여부 결정
+func compressed(output string) bool {
+	return strings.HasSuffix(output, ".gz") || strings.HasSuffix(output, ".tgz")
+}
+
+// writeArchive 출력 파일을 만들고 write로 내용을 기록 (실패하면 만들던 파일 삭제)
+func writeArchive(output string, write func(w io.Writer) error) error {
+	out, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("출력 파일 생성 실패: %w", err)
+	}
+	err = write(out)
+	if cerr := out.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("출력 파일 저장 실패: %w", cerr)
+	}
+	if err != nil {
+		os.Remove(output)
+	}
+	return err
+}
+
+// writeTar tar 아카이브 기록
+func writeTar(w io.Writer, root string, files []string, gz bool) error {
+	if gz {
+		zw := gzip.NewWriter(w)
+		if err := writeTar(zw, root, files, false); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("압축 실패: %w", err)
+		}
+		return nil
+	}
+
+	tw := tar.NewWriter(w)
+	for _, rel := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("파일 정보 확인 실패: %w", err)
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return fmt.Errorf("tar 헤더 생성 실패: %s: %w", rel, err)
+		}
+		header.Name = rel
+		header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("tar 기록 실패: %w", err)
+		}
+		if err := copyContent(tw, path); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("tar 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// writeZip zip 아카이브 기록
+func writeZip(w io.Writer, root string, files []string) error {
+	zw := zip.NewWriter(w)
+	for _, rel := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("파일 정보 확인 실패: %w", err)
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return fmt.Errorf("zip 헤더 생성 실패: %s: %w", rel, err)
+		}
+		header.Name = rel
+		header.Method = zip.Deflate
+		entry, err := zw.CreateHeader(header)
+		if err != nil {
+			return fmt.Errorf("zip 기록 실패: %w", err)
+		}
+		if err := copyContent(entry, path); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("zip 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// copyContent 파일 내용을 아카이브 항목에 복사
+func copyContent(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(w, file); err != nil {
+		return fmt.Errorf("아카이브 기록 실패: %s: %w", path, err)
+	}
+	return nil
+}
+
+// Check 형식이 지원되고 필요한 도구가 있는지 확인 (오래 걸리는 전송 전에 호출)
+func Check(format string) error {
+	switch format {
+	case FormatTar, FormatZip:
+		return nil
+	case FormatISO:
+		_, err := isoCommand("", "", "")
+		return err
+	}
+	return fmt.Errorf("지원하지 않는 형식입니다: %s (%s 중 하나)", format, strings.Join(Formats, ", "))
+}
+
+// writeISO 설치된 ISO 생성 도구로 루트 디렉토리의 이미지 생성
+func writeISO(output, root, label string) error {
+	cmd, err := isoCommand(output, root, label)
+	if err != nil {
+		return err
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		os.Remove(output)
+		return fmt.Errorf("ISO 생성 실패 (%s): %w: %s", filepath.Base(cmd.Path), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// isoCommand ISO 생성 명령어 (xorriso, mkisofs, genisoimage, macOS의 hdiutil 순으로 사용)
+func isoCommand(output, root, label string) (*exec.Cmd, error) {
+	// 볼륨 ID는 32바이트까지이므로 UTF-8 문자 중간에서 자르지 않도록 문자 단위로 줄임
+	for len(label) > 32 {
+		_, size := utf8.DecodeLastRuneInString(label)
+		label = label[:len(label)-size]
+	}
+
+	switch {
+	case runtime.GOOS == "darwin" && lookPath("hdiutil"):
+		return exec.Command("hdiutil", "makehybrid", "-iso", "-joliet", "-default-volume-name", label, "-o", output, root), nil
+	case lookPath("xorriso"):
+		return exec.Command("xorriso", "-as", "mkisofs", "-iso-level", "3", "-R", "-J", "-V", label, "-o", output, root), nil
+	case lookPath("mkisofs"):
+		return exec.Command("mkisofs", "-iso-level", "3", "-R", "-J", "-V", label, "-o", output, root), nil
+	case lookPath("genisoimage"):
+		return exec.Command("genisoimage", "-iso-level", "3", "-R", "-J", "-V", label, "-o", output, root), nil
+	}
+	return nil, fmt.Errorf("ISO 생성 도구를 찾을 수 없습니다 (xorriso, mkisofs, genisoimage 또는 macOS의 hdiutil 필요)")
+}
+
+// lookPath 실행 파일이 PATH에 있는지 확인
+func lookPath(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
@@ -0,0 +1,57 @@
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+
+	"sync-tool/internal/logger"
+)
+
+// 훅 이름
+const (
+	PrePlan  = "pre_plan"
+	PreSync  = "pre_sync"
+	PostSync = "post_sync"
+	OnError  = "on_error"
+)
+
+// Run 훅 명령어를 셸로 실행 (명령어가 비어 있으면 아무것도 하지 않음)
+// vars는 현재 환경 변수에 추가되며, 0이 아닌 종료 코드는 오류로 반환됩니다.
+func Run(hook string, command string, vars map[string]string, out io.Writer) error {
+	if command == "" {
+		return nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	cmd.Env = os.Environ()
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		cmd.Env = append(cmd.Env, key+"="+vars[key])
+	}
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	logger.Infof("%s 훅 실행: %s", hook, command)
+
+	if err := cmd.Run(); err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("%s 훅 실패 (exit code %d)", hook, exitError.ExitCode())
+		}
+		return fmt.Errorf("%s 훅 실행 실패: %w", hook, err)
+	}
+	return nil
+}
@@ -63,6 +63,14 @@ func GetLogger() *logrus.Logger {
 	return log
 }
 
+// Fields 구조화 로그 필드
+type Fields = logrus.Fields
+
+// WithFields 필드가 포함된 로그 항목 반환
+func WithFields(fields Fields) *logrus.Entry {
+	return GetLogger().WithFields(fields)
+}
+
 // Debug 디버그 로그
 func Debug(args ...interface{}) {
 	GetLogger().Debug(args...)
@@ -0,0 +1,181 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry 매니페스트에 기록된 파일 하나
+type Entry struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mtime"`
+	SHA256  string    `json:"sha256"`
+}
+
+// Manifest 대상 경로의 파일 목록과 내용 해시
+type Manifest struct {
+	Profile string    `json:"profile"`
+	Target  string    `json:"target"`
+	Created time.Time `json:"created"`
+	Entries []Entry   `json:"entries"`
+}
+
+// Build 루트 아래의 모든 일반 파일을 해시하여 매니페스트 생성
+// skip에 있는 이름의 디렉토리(부분 전송, 스테이징, 백업 등)는 건너뜀
+func Build(root string, skip []string) (*Manifest, error) {
+	files, err := Files(root, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{Target: root, Created: time.Now(), Entries: make([]Entry, 0, len(files))}
+	for _, rel := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("매니페스트 생성 실패: %w", err)
+		}
+		sum, err := HashFile(path)
+		if err != nil {
+			return nil, err
+		}
+		m.Entries = append(m.Entries, Entry{Path: rel, Size: info.Size(), ModTime: info.ModTime(), SHA256: sum})
+	}
+	return m, nil
+}
+
+// Files 루트 아래 일반 파일의 상대 경로 목록 (슬래시 구분, 이름순)
+func Files(root string, skip []string) ([]string, error) {
+	skipped := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		skipped[name] = true
+	}
+
+	files := []string{}
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && skipped[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() {
+			rel, _ := filepath.Rel(root, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("파일 목록 읽기 실패: %w", err)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// Index 경로별 항목 맵
+func (m *Manifest) Index() map[string]Entry {
+	index := make(map[string]Entry, len(m.Entries))
+	for _, entry := range m.Entries {
+		index[entry.Path] = entry
+	}
+	return index
+}
+
+// TotalSize 전체 파일 크기 합계
+func (m *Manifest) TotalSize() int64 {
+	var total int64
+	for _, entry := range m.Entries {
+		total += entry.Size
+	}
+	return total
+}
+
+// Update 동기화로 바뀐 경로만 다시 해시하여 항목 갱신 (없어진 경로는 그 아래 항목까지 제거)
+func (m *Manifest) Update(root string, paths []string) error {
+	index := m.Index()
+	for _, rel := range paths {
+		rel = strings.TrimSuffix(rel, "/")
+		path := filepath.Join(root, filepath.FromSlash(rel))
+
+		info, err := os.Stat(path)
+		switch {
+		case os.IsNotExist(err):
+			delete(index, rel)
+			for existing := range index {
+				if strings.HasPrefix(existing, rel+"/") {
+					delete(index, existing)
+				}
+			}
+		case err != nil:
+			return fmt.Errorf("매니페스트 갱신 실패: %w", err)
+		case info.Mode().IsRegular():
+			if entry, ok := index[rel]; ok && entry.Size == info.Size() && entry.ModTime.Equal(info.ModTime()) {
+				continue
+			}
+			sum, err := HashFile(path)
+			if err != nil {
+				return err
+			}
+			index[rel] = Entry{Path: rel, Size: info.Size(), ModTime: info.ModTime(), SHA256: sum}
+		}
+	}
+
+	m.Entries = make([]Entry, 0, len(index))
+	for _, entry := range index {
+		m.Entries = append(m.Entries, entry)
+	}
+	sort.Slice(m.Entries, func(i, j int) bool { return m.Entries[i].Path < m.Entries[j].Path })
+	m.Created = time.Now()
+	return nil
+}
+
+// Matches 파일이 항목과 같은 내용인지 확인 (크기와 수정 시각이 같으면 해시는 생략)
+func Matches(path string, entry Entry) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Size() != entry.Size {
+		return false
+	}
+	if info.ModTime().Equal(entry.ModTime) {
+		return true
+	}
+	sum, err := HashFile(path)
+	return err == nil && sum == entry.SHA256
+}
+
+// Verify 파일 내용이 항목의 해시와 같은지 확인 (수정 시각과 관계없이 항상 해시 비교)
+func Verify(path string, entry Entry) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Size() != entry.Size {
+		return false
+	}
+	sum, err := HashFile(path)
+	return err == nil && sum == entry.SHA256
+}
+
+// HashFile 파일 내용의 SHA-256 해시
+func HashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("파일 해시 계산 실패: %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
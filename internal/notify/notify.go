@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+
+	"sync-tool/internal/logger"
+)
+
+// Send 데스크톱 알림 표시 (알림 도구가 없으면 무시)
+func Send(title, message string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleString(message), appleString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux":
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			logger.Debugf("notify-send가 없어 데스크톱 알림을 건너뜀: %s", title)
+			return
+		}
+		cmd = exec.Command("notify-send", title, message)
+	default:
+		return
+	}
+
+	if err := cmd.Run(); err != nil {
+		logger.Debugf("데스크톱 알림 실패: %v", err)
+	}
+}
+
+// Confirm 데스크톱 대화상자로 예/아니오 확인 (Linux는 zenity 또는 kdialog, macOS는 osascript)
+func Confirm(title, message string) (bool, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf(`display dialog %s with title %s buttons {"취소", "동기화"} default button "동기화" cancel button "취소"`,
+			appleString(message), appleString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux":
+		if _, err := exec.LookPath("zenity"); err == nil {
+			cmd = exec.Command("zenity", "--question", "--title", title, "--text", message)
+		} else if _, err := exec.LookPath("kdialog"); err == nil {
+			cmd = exec.Command("kdialog", "--title", title, "--yesno", message)
+		} else {
+			return false, fmt.Errorf("데스크톱 확인 도구(zenity, kdialog)를 찾을 수 없습니다")
+		}
+	default:
+		return false, fmt.Errorf("데스크톱 확인을 지원하지 않는 플랫폼입니다: %s", runtime.GOOS)
+	}
+
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			// 취소 버튼은 0이 아닌 종료 코드로 반환됨
+			return false, nil
+		}
+		return false, fmt.Errorf("데스크톱 확인 실행 실패: %w", err)
+	}
+	return true, nil
+}
+
+// appleString AppleScript 문자열 리터럴로 변환
+func appleString(value string) string {
+	return strconv.Quote(value)
+}
@@ -0,0 +1,137 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{"*/15 * * * *", false},
+		{"0 2 * * 1-5", false},
+		{"0,30 8-18/2 1 1,7 *", false},
+		{"0 0 * * 7", false},
+		{"@daily", false},
+		{" @hourly ", false},
+		{"", true},
+		{"* * * *", true},
+		{"* * * * * *", true},
+		{"60 * * * *", true},
+		{"* 24 * * *", true},
+		{"* * 0 * *", true},
+		{"* * * 13 *", true},
+		{"* * * * 8", true},
+		{"*/0 * * * *", true},
+		{"5-1 * * * *", true},
+		{"a * * * *", true},
+		{"0 0 31 2 *", true}, // 2월 31일은 없으므로 실행 시각이 없음
+	}
+
+	for _, tt := range tests {
+		_, err := ParseCron(tt.expr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCron(%q) 오류 = %v, 오류 기대 = %v", tt.expr, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{"*/15 * * * *", at(2026, 3, 1, 10, 7), at(2026, 3, 1, 10, 15)},
+		{"*/15 * * * *", at(2026, 3, 1, 10, 15), at(2026, 3, 1, 10, 30)},
+		{"0 2 * * *", at(2026, 3, 1, 3, 0), at(2026, 3, 2, 2, 0)},
+		{"30 1 1 * *", at(2026, 1, 31, 0, 0), at(2026, 2, 1, 1, 30)},
+		{"0 9 * * 1-5", at(2026, 10, 17, 12, 0), at(2026, 10, 19, 9, 0)}, // 토요일 → 월요일
+		{"0 0 * * 7", at(2026, 3, 1, 0, 0), at(2026, 3, 8, 0, 0)},        // 7은 일요일
+		{"0 0 13 * 5", at(2026, 3, 1, 0, 0), at(2026, 3, 6, 0, 0)},       // 일과 요일 중 하나만 맞아도 실행
+		{"0 0 13 * 5", at(2026, 3, 7, 0, 0), at(2026, 3, 13, 0, 0)},
+		{"@monthly", at(2026, 12, 15, 0, 0), at(2027, 1, 1, 0, 0)},
+		{"0 0 29 2 *", at(2026, 3, 1, 0, 0), at(2028, 2, 29, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		cron, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) 실패: %v", tt.expr, err)
+		}
+		if got := cron.Next(tt.after); !got.Equal(tt.want) {
+			t.Errorf("%q.Next(%v) = %v, 기대값 %v", tt.expr, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		text       string
+		start, end int
+		wantErr    bool
+	}{
+		{"09:00-17:30", 540, 1050, false},
+		{"19:00-07:00", 1140, 420, false},
+		{" 22:00 - 24:00 ", 1320, 1440, false},
+		{"0:05-1:00", 5, 60, false},
+		{"09:00", 0, 0, true},
+		{"09:00-09:00", 0, 0, true},
+		{"25:00-01:00", 0, 0, true},
+		{"24:30-01:00", 0, 0, true},
+		{"09:60-10:00", 0, 0, true},
+		{"9-17", 0, 0, true},
+		{"", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		window, err := ParseWindow(tt.text)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseWindow(%q) 오류 = %v, 오류 기대 = %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (window.Start != tt.start || window.End != tt.end) {
+			t.Errorf("ParseWindow(%q) = %d-%d, 기대값 %d-%d", tt.text, window.Start, window.End, tt.start, tt.end)
+		}
+	}
+}
+
+func TestWindowContains(t *testing.T) {
+	tests := []struct {
+		window string
+		clock  string
+		want   bool
+	}{
+		{"09:00-17:30", "09:00", true},
+		{"09:00-17:30", "17:29", true},
+		{"09:00-17:30", "17:30", false},
+		{"09:00-17:30", "08:59", false},
+		{"19:00-07:00", "23:00", true},
+		{"19:00-07:00", "00:00", true},
+		{"19:00-07:00", "06:59", true},
+		{"19:00-07:00", "07:00", false},
+		{"19:00-07:00", "12:00", false},
+		{"22:00-24:00", "23:59", true},
+		{"22:00-24:00", "00:00", false},
+	}
+
+	for _, tt := range tests {
+		window, err := ParseWindow(tt.window)
+		if err != nil {
+			t.Fatalf("ParseWindow(%q) 실패: %v", tt.window, err)
+		}
+		clock, err := time.Parse("15:04", tt.clock)
+		if err != nil {
+			t.Fatalf("시각 파싱 실패: %v", err)
+		}
+		if got := window.Contains(clock); got != tt.want {
+			t.Errorf("%q.Contains(%s) = %v, 기대값 %v", tt.window, tt.clock, got, tt.want)
+		}
+	}
+}
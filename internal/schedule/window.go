@@ -0,0 +1,88 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window 하루 중 시간대 (예: "19:00-07:00"은 자정을 넘어감)
+type Window struct {
+	Start int // 자정 기준 분
+	End   int // 자정 기준 분 (24:00은 1440)
+	text  string
+}
+
+// ParseWindow "HH:MM-HH:MM" 형식의 시간대 파싱
+func ParseWindow(text string) (Window, error) {
+	startText, endText, ok := strings.Cut(strings.TrimSpace(text), "-")
+	if !ok {
+		return Window{}, fmt.Errorf("시간대는 HH:MM-HH:MM 형식이어야 합니다: %q", text)
+	}
+
+	start, err := parseClock(startText)
+	if err != nil {
+		return Window{}, fmt.Errorf("잘못된 시간대 %q: %w", text, err)
+	}
+	end, err := parseClock(endText)
+	if err != nil {
+		return Window{}, fmt.Errorf("잘못된 시간대 %q: %w", text, err)
+	}
+	if start == end {
+		return Window{}, fmt.Errorf("시작과 끝이 같은 시간대입니다: %q", text)
+	}
+	return Window{Start: start, End: end, text: text}, nil
+}
+
+// ParseWindows 여러 시간대 파싱
+func ParseWindows(texts []string) ([]Window, error) {
+	windows := make([]Window, 0, len(texts))
+	for _, text := range texts {
+		window, err := ParseWindow(text)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// String 원래 시간대 문자열
+func (w Window) String() string {
+	return w.text
+}
+
+// Contains 시각이 시간대 안에 있는지 확인
+func (w Window) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if w.Start < w.End {
+		return minute >= w.Start && minute < w.End
+	}
+	// 자정을 넘어가는 시간대
+	return minute >= w.Start || minute < w.End
+}
+
+// InAny 시간대 목록 중 하나에 포함되는지 확인 (목록이 비어 있으면 항상 허용)
+func InAny(windows []Window, t time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	for _, window := range windows {
+		if window.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock "HH:MM"을 자정 기준 분으로 변환
+func parseClock(text string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(strings.TrimSpace(text), "%d:%d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("시각은 HH:MM 형식이어야 합니다: %q", text)
+	}
+	if hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("잘못된 시각: %q", text)
+	}
+	return hour*60 + minute, nil
+}
@@ -0,0 +1,67 @@
+package state
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// DevicesFile 장치별 기록 파일 이름
+const DevicesFile = "devices.json"
+
+// DeviceRecord USB 장치 한 개의 누적 기록
+type DeviceRecord struct {
+	ID          string               `json:"id"`
+	Label       string               `json:"label,omitempty"`
+	UUID        string               `json:"uuid,omitempty"`
+	Serial      string               `json:"serial,omitempty"`
+	FSType      string               `json:"fs_type,omitempty"`
+	Capacity    uint64               `json:"capacity,omitempty"`
+	FirstSeen   time.Time            `json:"first_seen"`
+	LastSeen    time.Time            `json:"last_seen"`
+	LastSync    time.Time            `json:"last_sync,omitempty"`
+	LastProfile string               `json:"last_profile,omitempty"`
+	LastResult  string               `json:"last_result,omitempty"`
+	Syncs       int                  `json:"syncs"`
+	Profiles    map[string]time.Time `json:"profiles,omitempty"`
+}
+
+// LoadDevices 장치별 기록 읽기 (파일이 없으면 빈 맵)
+func LoadDevices(dir string) (map[string]*DeviceRecord, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return loadDevices(dir)
+}
+
+// UpdateDevice 장치 기록을 읽어 fn으로 갱신한 뒤 저장 (없으면 새로 생성)
+func UpdateDevice(dir, id string, fn func(record *DeviceRecord)) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	records, err := loadDevices(dir)
+	if err != nil {
+		return err
+	}
+
+	record, exists := records[id]
+	if !exists {
+		record = &DeviceRecord{ID: id, FirstSeen: time.Now()}
+		records[id] = record
+	}
+	fn(record)
+
+	return saveDevices(dir, records)
+}
+
+// loadDevices 잠금 없이 장치 기록 읽기
+func loadDevices(dir string) (map[string]*DeviceRecord, error) {
+	records := make(map[string]*DeviceRecord)
+	if _, err := readJSON(filepath.Join(dir, DevicesFile), &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// saveDevices 장치 기록 저장
+func saveDevices(dir string, records map[string]*DeviceRecord) error {
+	return writeJSON(filepath.Join(dir, DevicesFile), records)
+}
@@ -0,0 +1,44 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// readJSON 상태 파일 읽기 (파일이 없으면 false 반환)
+func readJSON(path string, v interface{}) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("상태 파일 읽기 실패: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("상태 파일 파싱 실패: %s: %w", filepath.Base(path), err)
+	}
+	return true, nil
+}
+
+// writeJSON 임시 파일에 쓴 뒤 교체하여 상태 파일 저장 (중간에 종료되어도 이전 내용 유지)
+func writeJSON(path string, v interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("상태 디렉토리 생성 실패: %w", err)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("상태 마샬링 실패: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("상태 파일 저장 실패: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("상태 파일 저장 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	gosync "sync"
+	"time"
+)
+
+// HistoryFile 동기화 기록 파일 이름 (상태 디렉토리 아래, 한 줄에 JSON 하나)
+const HistoryFile = "history.jsonl"
+
+// 동기화 결과 값
+const (
+	ResultSuccess     = "success"
+	ResultFailure     = "failure"
+	ResultInterrupted = "interrupted" // Ctrl-C나 SIGTERM으로 중단됨
+)
+
+// KindCheckout 일부 경로만 받은 checkout 실행 기록 (비어 있으면 전체 동기화)
+const KindCheckout = "checkout"
+
+// mu 같은 프로세스의 동시 작업이 상태 파일을 함께 갱신할 때 사용
+var mu gosync.Mutex
+
+// HistoryEntry 동기화 실행 기록
+type HistoryEntry struct {
+	Time      time.Time     `json:"time"`
+	Profile   string        `json:"profile"`
+	Kind      string        `json:"kind,omitempty"`
+	Target    string        `json:"target"`
+	DeviceID  string        `json:"device_id,omitempty"`
+	Label     string        `json:"label,omitempty"`
+	Result    string        `json:"result"`
+	Changes   int           `json:"changes"`
+	Deletions int           `json:"deletions"`
+	Bytes     int64         `json:"bytes"`
+	Attempts  int           `json:"attempts,omitempty"`
+	Copied    int           `json:"copied,omitempty"`  // 실제로 복사를 마친 파일 수
+	Removed   int           `json:"removed,omitempty"` // 실제로 삭제를 마친 파일 수
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// AppendHistory 동기화 기록 추가
+func AppendHistory(dir string, entry HistoryEntry) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("상태 디렉토리 생성 실패: %w", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("동기화 기록 마샬링 실패: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, HistoryFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("동기화 기록 파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("동기화 기록 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// LoadHistory 전체 동기화 기록을 오래된 순으로 읽기 (파일이 없으면 빈 목록)
+func LoadHistory(dir string) ([]HistoryEntry, error) {
+	file, err := os.Open(filepath.Join(dir, HistoryFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("동기화 기록 파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	entries := []HistoryEntry{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// 중간에 끊긴 줄은 건너뜀
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("동기화 기록 읽기 실패: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,267 @@
+package state
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	gosync "sync"
+	"time"
+)
+
+// JournalDir 진행 중인 동기화 저널 디렉토리 이름 (상태 디렉토리 아래)
+const JournalDir = "journals"
+
+// JournalChange 계획된 복사 항목
+type JournalChange struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+// JournalPlan 저널 첫 줄에 기록되는 동기화 계획
+type JournalPlan struct {
+	Profile   string          `json:"profile"`
+	Target    string          `json:"target"`
+	Created   time.Time       `json:"created"`
+	Changes   []JournalChange `json:"changes"`
+	Deletions []string        `json:"deletions"`
+	Bytes     int64           `json:"bytes"`
+}
+
+// journalRecord 저널 진행 기록 한 줄
+type journalRecord struct {
+	Plan    *JournalPlan `json:"plan,omitempty"`
+	Done    string       `json:"done,omitempty"`
+	Deleted string       `json:"deleted,omitempty"`
+	PID     int          `json:"pid,omitempty"` // 저널에 기록하기 시작한 프로세스
+}
+
+// Journal 진행 중인 동기화의 계획과 완료 항목 (한 줄씩 추가 기록하여 중간에 종료되어도 유지)
+type Journal struct {
+	Path      string
+	Plan      JournalPlan
+	Completed map[string]bool
+	Deleted   map[string]bool
+	Updated   time.Time
+	PID       int // 마지막으로 저널을 연 프로세스 (실행 중이면 동기화가 진행 중)
+
+	mu   gosync.Mutex
+	file *os.File
+}
+
+// JournalPath 프로필과 대상 경로의 저널 파일 경로
+func JournalPath(dir, profileID, target string) string {
+	return filepath.Join(dir, JournalDir, targetName(profileID, target)+".jsonl")
+}
+
+// targetName 프로필과 대상 경로를 구분하는 파일 이름 (확장자 제외)
+func targetName(profileID, target string) string {
+	return fmt.Sprintf("%s-%s", profileID, TargetKey(target))
+}
+
+// TargetKey 대상 경로를 구분하는 짧은 해시 (같은 프로필의 대상별 상태를 나눌 때 사용)
+func TargetKey(target string) string {
+	sum := sha256.Sum256([]byte(filepath.Clean(target)))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// CreateJournal 새 저널 생성 (같은 대상의 이전 저널은 교체)
+func CreateJournal(dir string, plan JournalPlan) (*Journal, error) {
+	if plan.Created.IsZero() {
+		plan.Created = time.Now()
+	}
+
+	path := JournalPath(dir, plan.Profile, plan.Target)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("저널 디렉토리 생성 실패: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("저널 생성 실패: %w", err)
+	}
+
+	journal := &Journal{
+		Path:      path,
+		Plan:      plan,
+		Completed: make(map[string]bool),
+		Deleted:   make(map[string]bool),
+		Updated:   time.Now(),
+		PID:       os.Getpid(),
+		file:      file,
+	}
+	if err := journal.append(journalRecord{Plan: &plan, PID: journal.PID}); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return journal, nil
+}
+
+// OpenJournal 기존 저널을 읽고 이어서 기록할 수 있도록 열기 (없으면 nil)
+func OpenJournal(dir, profileID, target string) (*Journal, error) {
+	journal, err := ReadJournal(JournalPath(dir, profileID, target))
+	if err != nil || journal == nil {
+		return journal, err
+	}
+
+	file, err := os.OpenFile(journal.Path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("저널 열기 실패: %w", err)
+	}
+	journal.file = file
+	journal.PID = os.Getpid()
+	if err := journal.append(journalRecord{PID: journal.PID}); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return journal, nil
+}
+
+// ReadJournal 저널 파일 읽기 (없으면 nil, 마지막에 끊긴 줄은 무시)
+func ReadJournal(path string) (*Journal, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("저널 읽기 실패: %w", err)
+	}
+	defer file.Close()
+
+	journal := &Journal{
+		Path:      path,
+		Completed: make(map[string]bool),
+		Deleted:   make(map[string]bool),
+	}
+	if info, err := file.Stat(); err == nil {
+		journal.Updated = info.ModTime()
+	}
+
+	hasPlan := false
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var record journalRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if record.PID != 0 {
+			journal.PID = record.PID
+		}
+		switch {
+		case record.Plan != nil:
+			journal.Plan = *record.Plan
+			hasPlan = true
+		case record.Done != "":
+			journal.Completed[record.Done] = true
+		case record.Deleted != "":
+			journal.Deleted[record.Deleted] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("저널 읽기 실패: %w", err)
+	}
+	if !hasPlan {
+		return nil, fmt.Errorf("저널에 동기화 계획이 없습니다: %s", path)
+	}
+	return journal, nil
+}
+
+// Active 저널을 기록하는 동기화가 아직 실행 중인지 확인
+func (j *Journal) Active() bool {
+	return j.PID != 0 && j.PID != os.Getpid() && processRunning(j.PID)
+}
+
+// ListJournals 상태 디렉토리의 모든 저널 파일 경로
+func ListJournals(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, JournalDir, "*.jsonl"))
+	if err != nil {
+		return nil, fmt.Errorf("저널 목록 조회 실패: %w", err)
+	}
+	return paths, nil
+}
+
+// MarkCompleted 복사가 끝난 파일 기록
+func (j *Journal) MarkCompleted(path string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.Completed[path] {
+		return nil
+	}
+	j.Completed[path] = true
+	return j.append(journalRecord{Done: path})
+}
+
+// MarkDeleted 삭제가 끝난 파일 기록
+func (j *Journal) MarkDeleted(path string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.Deleted[path] {
+		return nil
+	}
+	j.Deleted[path] = true
+	return j.append(journalRecord{Deleted: path})
+}
+
+// Remaining 아직 끝나지 않은 복사 항목과 삭제 항목
+func (j *Journal) Remaining() ([]JournalChange, []string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	changes := []JournalChange{}
+	for _, change := range j.Plan.Changes {
+		if !j.Completed[change.Path] {
+			changes = append(changes, change)
+		}
+	}
+	deletions := []string{}
+	for _, deletion := range j.Plan.Deletions {
+		if !j.Deleted[deletion] {
+			deletions = append(deletions, deletion)
+		}
+	}
+	return changes, deletions
+}
+
+// Close 저널 파일 닫기 (파일은 --resume을 위해 남김)
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// Remove 저널 닫고 삭제 (동기화가 끝났을 때)
+func (j *Journal) Remove() error {
+	j.Close()
+	if err := os.Remove(j.Path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("저널 삭제 실패: %w", err)
+	}
+	return nil
+}
+
+// append 기록 한 줄 추가 (j.mu를 잡은 상태에서 호출)
+func (j *Journal) append(record journalRecord) error {
+	if j.file == nil {
+		return fmt.Errorf("저널이 닫혀 있습니다: %s", j.Path)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("저널 기록 마샬링 실패: %w", err)
+	}
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("저널 기록 실패: %w", err)
+	}
+	j.Updated = time.Now()
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package state
+
+import "os"
+
+// processRunning 프로세스가 실행 중인지 확인 (확인할 수 없으면 실행 중으로 간주)
+func processRunning(pid int) bool {
+	_, err := os.FindProcess(pid)
+	return err == nil
+}
@@ -0,0 +1,15 @@
+//go:build linux || darwin
+
+package state
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+// processRunning 프로세스가 실행 중인지 확인 (신호 0으로 존재 여부만 확인)
+func processRunning(pid int) bool {
+	err := unix.Kill(pid, 0)
+	return err == nil || errors.Is(err, unix.EPERM)
+}
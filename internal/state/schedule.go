@@ -0,0 +1,38 @@
+package state
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// ScheduleFile 예약 실행 상태 파일 이름
+const ScheduleFile = "schedule.json"
+
+// ScheduleState 프로필별 예약 실행 상태 (daemon 재시작 후에도 유지)
+type ScheduleState struct {
+	Cron       string    `json:"cron,omitempty"` // NextRun을 계산한 표현식 (바뀌면 다시 계산)
+	LastRun    time.Time `json:"last_run,omitempty"`
+	LastResult string    `json:"last_result,omitempty"`
+	LastError  string    `json:"last_error,omitempty"`
+	NextRun    time.Time `json:"next_run"`
+}
+
+// LoadSchedule 예약 실행 상태 읽기 (파일이 없으면 빈 맵)
+func LoadSchedule(dir string) (map[string]*ScheduleState, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	states := make(map[string]*ScheduleState)
+	if _, err := readJSON(filepath.Join(dir, ScheduleFile), &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// SaveSchedule 예약 실행 상태 저장
+func SaveSchedule(dir string, states map[string]*ScheduleState) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return writeJSON(filepath.Join(dir, ScheduleFile), states)
+}
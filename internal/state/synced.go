@@ -0,0 +1,30 @@
+package state
+
+import (
+	"path/filepath"
+
+	"sync-tool/internal/manifest"
+)
+
+// SyncedDir 마지막 동기화 직후의 대상 매니페스트 디렉토리 이름 (상태 디렉토리 아래)
+const SyncedDir = "synced"
+
+// SyncedPath 프로필과 대상 경로의 동기화 매니페스트 파일 경로
+func SyncedPath(dir, profileID, target string) string {
+	return filepath.Join(dir, SyncedDir, targetName(profileID, target)+".json")
+}
+
+// SaveSynced 동기화 직후의 대상 매니페스트 저장
+func SaveSynced(dir string, m *manifest.Manifest) error {
+	return writeJSON(SyncedPath(dir, m.Profile, m.Target), m)
+}
+
+// LoadSynced 마지막 동기화 매니페스트 읽기 (기록이 없으면 nil)
+func LoadSynced(dir, profileID, target string) (*manifest.Manifest, error) {
+	var m manifest.Manifest
+	found, err := readJSON(SyncedPath(dir, profileID, target), &m)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,61 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sync-tool/internal/manifest"
+)
+
+// TagsDir 태그 매니페스트 디렉토리 이름 (상태 디렉토리 아래, 프로필별 하위 디렉토리)
+const TagsDir = "tags"
+
+// TagPath 프로필 태그 매니페스트 파일 경로
+func TagPath(dir, profileID, name string) string {
+	return filepath.Join(dir, TagsDir, profileID, name+".json")
+}
+
+// SaveTag 태그 매니페스트 저장
+func SaveTag(dir, profileID, name string, m *manifest.Manifest) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("잘못된 태그 이름: %q", name)
+	}
+	return writeJSON(TagPath(dir, profileID, name), m)
+}
+
+// LoadTag 태그 매니페스트 읽기
+func LoadTag(dir, profileID, name string) (*manifest.Manifest, error) {
+	var m manifest.Manifest
+	found, err := readJSON(TagPath(dir, profileID, name), &m)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("태그를 찾을 수 없습니다: %s (%s)", name, profileID)
+	}
+	return &m, nil
+}
+
+// ListTags 프로필의 태그 이름 목록 (이름순)
+func ListTags(dir, profileID string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, TagsDir, profileID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("태그 디렉토리 읽기 실패: %w", err)
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
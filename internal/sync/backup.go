@@ -0,0 +1,303 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/state"
+)
+
+// BackupStampFormat 백업 회차 디렉토리 이름 형식 (동기화 시작 시각)
+const BackupStampFormat = "20060102-150405"
+
+// BackupVersion 백업된 파일의 이전 버전
+type BackupVersion struct {
+	Time time.Time // 이 버전이 교체되거나 삭제된 동기화 시각
+	Path string    // 백업 파일 경로
+	Size int64
+}
+
+// BackupRoot 프로필 백업 디렉토리 (대상에 보관하면 대상 경로 아래, 로컬이면 프로필과 대상별 하위 디렉토리)
+// 로컬에 보관할 때 대상까지 나누지 않으면 여러 USB의 백업이 섞여 다른 USB의 파일이 복원될 수 있음
+func (s *SyncEngine) BackupRoot(profile *config.SyncProfile) string {
+	backup := profile.GetBackup()
+	if backup.OnTarget() {
+		return filepath.Join(profile.LocalPath, backup.Dir)
+	}
+	return filepath.Join(backup.Dir, profile.ID, state.TargetKey(profile.LocalPath))
+}
+
+// ArtifactDirs 동기화 중 대상 경로 아래에 생기는 디렉토리 이름 (부분 전송, 스테이징, 백업)
+func (s *SyncEngine) ArtifactDirs(profile *config.SyncProfile) []string {
+	dirs := []string{s.PartialDir(profile), StagingDir}
+	if backup := profile.GetBackup(); backup.OnTarget() {
+		dirs = append(dirs, filepath.Base(backup.Dir))
+	}
+	return dirs
+}
+
+// startBackup 이번 동기화의 백업 회차 디렉토리 설정 (백업을 사용하지 않으면 비움)
+func (s *SyncEngine) startBackup(profile *config.SyncProfile) {
+	s.backupDir = ""
+	if profile.GetBackup().Enabled {
+		s.backupDir = newBackupDir(s.BackupRoot(profile))
+	}
+}
+
+// newBackupDir 아직 없는 백업 회차 디렉토리 경로 (같은 초에 이미 있으면 다음 초 사용)
+func newBackupDir(root string) string {
+	stamp := time.Now()
+	for {
+		dir := filepath.Join(root, stamp.Format(BackupStampFormat))
+		if _, err := os.Lstat(dir); os.IsNotExist(err) {
+			return dir
+		}
+		stamp = stamp.Add(time.Second)
+	}
+}
+
+// backupArgs rsync가 덮어쓰는 파일을 백업 회차 디렉토리로 옮기도록 하는 인자
+func (s *SyncEngine) backupArgs() []string {
+	if s.backupDir == "" {
+		return nil
+	}
+	return []string{"--backup", "--backup-dir=" + s.backupDir}
+}
+
+// backupFilter 대상에 보관한 백업이 --delete로 지워지지 않도록 보호하는 필터
+func backupFilter(profile *config.SyncProfile) []string {
+	backup := profile.GetBackup()
+	if !backup.OnTarget() {
+		return nil
+	}
+	return []string{"--filter=P /" + filepath.ToSlash(filepath.Clean(backup.Dir)) + "/"}
+}
+
+// backupCopy 교체될 파일을 백업 회차에 복사 (같은 볼륨이면 하드 링크)
+//
+// 하드 링크는 원본과 같은 inode이므로, 호출한 쪽은 반드시 새 파일을 따로 쓴 뒤 rename으로 교체해야 함
+// (스테이징 반영과 체크아웃이 그렇게 함). 원본 파일을 제자리에서 고쳐 쓰면 백업 내용도 함께 바뀜
+func (s *SyncEngine) backupCopy(fullPath, rel string) error {
+	if s.backupDir == "" {
+		return nil
+	}
+	if _, err := os.Lstat(fullPath); os.IsNotExist(err) {
+		return nil
+	}
+	dest := filepath.Join(s.backupDir, rel)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("백업 디렉토리 생성 실패: %w", err)
+	}
+	if err := os.Link(fullPath, dest); err == nil {
+		return nil
+	}
+	return CopyTree(fullPath, dest)
+}
+
+// backupMove 삭제할 파일을 백업 회차로 이동 (다른 볼륨이면 복사 후 삭제)
+func (s *SyncEngine) backupMove(fullPath, rel string) error {
+	dest := filepath.Join(s.backupDir, rel)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("백업 디렉토리 생성 실패: %w", err)
+	}
+	if err := os.Rename(fullPath, dest); err == nil {
+		return nil
+	}
+	if err := CopyTree(fullPath, dest); err != nil {
+		return err
+	}
+	return os.RemoveAll(fullPath)
+}
+
+// BackupVersions 경로의 백업된 이전 버전 목록 (오래된 순)
+func (s *SyncEngine) BackupVersions(profile *config.SyncProfile, rel string) ([]BackupVersion, error) {
+	root := s.BackupRoot(profile)
+	stamps, err := backupStamps(root)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []BackupVersion{}
+	for _, stamp := range stamps {
+		path := filepath.Join(root, stamp.Format(BackupStampFormat), rel)
+		info, err := os.Lstat(path)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, BackupVersion{Time: stamp, Path: path, Size: info.Size()})
+	}
+	return versions, nil
+}
+
+// RestoreVersion 백업된 버전을 대상 경로에 복원 (현재 파일은 새 백업 회차로 옮겨 되돌릴 수 있게 함)
+func (s *SyncEngine) RestoreVersion(profile *config.SyncProfile, rel string, version BackupVersion) error {
+	s.backupDir = newBackupDir(s.BackupRoot(profile))
+	defer func() { s.backupDir = "" }()
+
+	target := filepath.Join(profile.LocalPath, rel)
+	if _, err := os.Lstat(target); err == nil {
+		if err := s.backupMove(target, rel); err != nil {
+			return fmt.Errorf("현재 파일 백업 실패: %w", err)
+		}
+	}
+	if err := CopyTree(version.Path, target); err != nil {
+		return fmt.Errorf("복원 실패: %w", err)
+	}
+	logger.Infof("백업 복원: %s (%s 버전)", target, version.Time.Format("2006-01-02 15:04:05"))
+	return nil
+}
+
+// PruneBackups keep 회차와 max_age를 넘은 오래된 백업 삭제
+func (s *SyncEngine) PruneBackups(profile *config.SyncProfile) error {
+	backup := profile.GetBackup()
+	maxAge, err := config.ParseDuration(backup.MaxAge)
+	if err != nil {
+		return fmt.Errorf("backup.max_age 오류: %w", err)
+	}
+
+	root := s.BackupRoot(profile)
+	stamps, err := backupStamps(root)
+	if err != nil {
+		return err
+	}
+
+	for i, stamp := range stamps {
+		expired := maxAge > 0 && time.Since(stamp) > maxAge
+		extra := backup.Keep > 0 && len(stamps)-i > backup.Keep
+		if !expired && !extra {
+			continue
+		}
+		dir := filepath.Join(root, stamp.Format(BackupStampFormat))
+		logger.Infof("오래된 백업 삭제: %s", dir)
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("백업 삭제 실패: %w", err)
+		}
+	}
+
+	// USB 공간을 차지하는 백업이 커지면 알림 (정리 후 기준)
+	if backup.OnTarget() {
+		warnSize, err := config.ParseSize(backup.WarnSize)
+		if err != nil {
+			return fmt.Errorf("backup.warn_size 오류: %w", err)
+		}
+		if size, err := DirSize(root); err == nil && warnSize > 0 && size > warnSize {
+			logger.Warnf("대상의 백업이 %s로 warn_size(%s)를 넘었습니다: %s (keep/max_age를 줄이거나 backup.dir을 로컬 절대 경로로 지정하세요)",
+				config.FormatSize(size), config.FormatSize(warnSize), root)
+		}
+	}
+	return nil
+}
+
+// validateBackup 백업 설정 값 확인
+func validateBackup(profile *config.SyncProfile) error {
+	backup := profile.GetBackup()
+	if backup.Keep < -1 {
+		return fmt.Errorf("backup.keep은 -1(제한 없음) 이상이어야 합니다: %d", backup.Keep)
+	}
+	if _, err := config.ParseDuration(backup.MaxAge); err != nil {
+		return fmt.Errorf("backup.max_age 오류: %w", err)
+	}
+	if _, err := config.ParseSize(backup.WarnSize); err != nil {
+		return fmt.Errorf("backup.warn_size 오류: %w", err)
+	}
+	if backup.OnTarget() && strings.Contains(backup.Dir, "..") {
+		return fmt.Errorf("backup.dir이 잘못되었습니다: %q", backup.Dir)
+	}
+	return nil
+}
+
+// backupStamps 백업 디렉토리의 회차 시각 목록 (오래된 순)
+func backupStamps(root string) ([]time.Time, error) {
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("백업 디렉토리 읽기 실패: %w", err)
+	}
+
+	stamps := []time.Time{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		stamp, err := time.ParseInLocation(BackupStampFormat, entry.Name(), time.Local)
+		if err != nil {
+			continue
+		}
+		stamps = append(stamps, stamp)
+	}
+	sort.Slice(stamps, func(i, j int) bool { return stamps[i].Before(stamps[j]) })
+	return stamps, nil
+}
+
+// CopyTree 파일이나 디렉토리를 수정 시각을 유지하며 복사
+func CopyTree(src, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dest, rel)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, 0755)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			os.Remove(target)
+			return os.Symlink(link, target)
+		default:
+			return copyFile(context.Background(), path, target, info)
+		}
+	})
+}
+
+// copyFile 파일 하나를 복사하고 수정 시각 유지 (ctx가 취소되면 복사를 멈춤)
+func copyFile(ctx context.Context, src, dest string, info os.FileInfo) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("파일 열기 실패: %w", err)
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %w", err)
+	}
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("파일 생성 실패: %w", err)
+	}
+	if _, err := io.Copy(out, contextReader{ctx: ctx, r: in}); err != nil {
+		out.Close()
+		return fmt.Errorf("파일 복사 실패: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("파일 복사 실패: %w", err)
+	}
+	return os.Chtimes(dest, info.ModTime(), info.ModTime())
+}
+
+// contextReader ctx가 취소되면 읽기를 멈추는 Reader (큰 파일을 복사하는 중에도 중단 요청을 반영)
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
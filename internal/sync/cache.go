@@ -0,0 +1,289 @@
+package sync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+)
+
+// CacheInfo fetch 캐시 메타데이터
+type CacheInfo struct {
+	ProfileID  string    `json:"profile_id"`
+	Host       string    `json:"host"`
+	ServerPath string    `json:"server_path"`
+	FetchedAt  time.Time `json:"fetched_at"`
+	Size       int64     `json:"size"`
+	Settings   string    `json:"settings"` // fetch에 사용한 옵션과 필터 인자의 해시
+}
+
+// Fetch 서버 경로를 로컬 캐시 디렉토리로 미러링
+func (s *SyncEngine) Fetch(ctx context.Context, profile *config.SyncProfile) (*CacheInfo, error) {
+	dataDir := s.cacheDataDir(profile)
+	logger.Infof("캐시 fetch 시작: 프로필=%s, 캐시경로=%s", profile.Name, dataDir)
+
+	if err := validateFilters(profile); err != nil {
+		return nil, err
+	}
+	if err := s.validateTransfer(profile); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("캐시 디렉토리 생성 실패: %w", err)
+	}
+
+	args := []string{}
+	args = append(args, profile.GetSyncOptions(s.config.Sync.Options)...)
+	args = append(args, "--no-perms", "--no-owner", "--no-group")
+	args = append(args, s.transferArgs(profile)...)
+	args = append(args, "--delete")
+	args = append(args, "-e", s.sshCommand(profile))
+	// 캐시에서 동기화할 때도 서버의 .syncignore가 적용되도록 캐시에는 함께 저장
+	args = append(args, "--filter=+ "+SyncIgnoreFile)
+	args = append(args, s.filterArgs(profile)...)
+	args = append(args, s.RemoteSource(profile), dataDir+"/")
+
+	cmd := s.rsyncCommand(ctx, profile, args)
+	cmd.Stdout = s.output
+	cmd.Stderr = s.output
+
+	logger.Debugf("캐시 fetch 명령어: %s", strings.Join(cmd.Args, " "))
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("캐시 fetch 중단: %w", ctx.Err())
+		}
+		return nil, fmt.Errorf("캐시 fetch 실패: %w", err)
+	}
+
+	size, err := DirSize(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("캐시 크기 계산 실패: %w", err)
+	}
+
+	info := &CacheInfo{
+		ProfileID:  profile.ID,
+		Host:       s.config.Server.Host,
+		ServerPath: profile.ServerPath,
+		FetchedAt:  time.Now(),
+		Size:       size,
+		Settings:   s.cacheSettings(profile),
+	}
+	if err := s.writeCacheInfo(profile, info); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("캐시 fetch 완료: %s (%s)", dataDir, config.FormatSize(size))
+
+	if err := s.PruneCache(profile.ID); err != nil {
+		logger.Warnf("캐시 정리 실패: %v", err)
+	}
+
+	return info, nil
+}
+
+// CacheStatus 프로필 캐시 정보와 사용 가능 여부 반환 (캐시가 없으면 nil)
+func (s *SyncEngine) CacheStatus(profile *config.SyncProfile) (*CacheInfo, bool) {
+	return s.freshCache(profile)
+}
+
+// PruneCache 만료된 캐시 제거 후 최대 크기를 넘으면 오래된 캐시부터 제거 (keep 프로필의 캐시는 크기 때문에 지우지 않음)
+func (s *SyncEngine) PruneCache(keep string) error {
+	cacheCfg := s.config.Sync.Cache
+
+	maxAge, err := config.ParseDuration(cacheCfg.MaxAge)
+	if err != nil {
+		return err
+	}
+	maxSize, err := config.ParseSize(cacheCfg.MaxSize)
+	if err != nil {
+		return err
+	}
+
+	infos, err := s.listCaches()
+	if err != nil {
+		return err
+	}
+
+	// 오래된 순으로 정렬
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].FetchedAt.Before(infos[j].FetchedAt)
+	})
+
+	var total int64
+	kept := []*CacheInfo{}
+	for _, info := range infos {
+		if maxAge > 0 && time.Since(info.FetchedAt) > maxAge {
+			logger.Infof("만료된 캐시 제거: %s (fetch 시각: %s)",
+				info.ProfileID, info.FetchedAt.Format("2006-01-02 15:04:05"))
+			if err := s.removeCache(info.ProfileID); err != nil {
+				return err
+			}
+			continue
+		}
+		total += info.Size
+		kept = append(kept, info)
+	}
+
+	for _, info := range kept {
+		if maxSize <= 0 || total <= maxSize {
+			break
+		}
+		if info.ProfileID == keep {
+			continue
+		}
+		logger.Infof("캐시 크기 초과로 제거: %s (%s)", info.ProfileID, config.FormatSize(info.Size))
+		if err := s.removeCache(info.ProfileID); err != nil {
+			return err
+		}
+		total -= info.Size
+	}
+	if maxSize > 0 && total > maxSize {
+		logger.Warnf("캐시 크기가 max_size(%s)를 넘습니다: %s (방금 fetch한 %s 캐시는 유지)",
+			config.FormatSize(maxSize), config.FormatSize(total), keep)
+	}
+
+	return nil
+}
+
+// CacheDir 프로필 캐시가 유효하면 캐시 데이터 디렉토리 반환
+func (s *SyncEngine) CacheDir(profile *config.SyncProfile) (string, bool) {
+	if _, fresh := s.freshCache(profile); !fresh {
+		return "", false
+	}
+	return s.cacheDataDir(profile), true
+}
+
+// freshCache 현재 프로필 설정과 일치하고 만료되지 않은 캐시인지 확인
+func (s *SyncEngine) freshCache(profile *config.SyncProfile) (*CacheInfo, bool) {
+	info, err := s.readCacheInfo(profile.ID)
+	if err != nil {
+		return nil, false
+	}
+
+	if info.Host != s.config.Server.Host || info.ServerPath != profile.ServerPath || info.Settings != s.cacheSettings(profile) {
+		logger.Debugf("캐시 설정 불일치, 무시: %s", profile.ID)
+		return info, false
+	}
+
+	maxAge, err := config.ParseDuration(s.config.Sync.Cache.MaxAge)
+	if err != nil {
+		logger.Warnf("캐시 max_age 설정 오류: %v", err)
+		return info, false
+	}
+	if maxAge > 0 && time.Since(info.FetchedAt) > maxAge {
+		logger.Debugf("캐시 만료됨: %s", profile.ID)
+		return info, false
+	}
+
+	if _, err := os.Stat(s.cacheDataDir(profile)); err != nil {
+		return info, false
+	}
+
+	return info, true
+}
+
+// cacheSettings 캐시 내용에 영향을 주는 옵션과 필터 인자의 해시 (바뀌면 캐시를 다시 fetch해야 함)
+func (s *SyncEngine) cacheSettings(profile *config.SyncProfile) string {
+	args := append([]string{}, profile.GetSyncOptions(s.config.Sync.Options)...)
+	args = append(args, s.filterArgs(profile)...)
+	sum := sha256.Sum256([]byte(strings.Join(args, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// listCaches 캐시 디렉토리의 모든 캐시 메타데이터 조회
+func (s *SyncEngine) listCaches() ([]*CacheInfo, error) {
+	entries, err := os.ReadDir(s.config.Sync.Cache.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("캐시 디렉토리 읽기 실패: %w", err)
+	}
+
+	infos := []*CacheInfo{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := s.readCacheInfo(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			logger.Warnf("캐시 메타데이터 읽기 실패: %s, 오류: %v", entry.Name(), err)
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// cacheDataDir 프로필 캐시 데이터 디렉토리
+func (s *SyncEngine) cacheDataDir(profile *config.SyncProfile) string {
+	return filepath.Join(s.config.Sync.Cache.Dir, profile.ID)
+}
+
+// cacheInfoPath 프로필 캐시 메타데이터 파일 경로 (rsync --delete 대상이 되지 않도록 데이터 디렉토리 밖에 저장)
+func (s *SyncEngine) cacheInfoPath(profileID string) string {
+	return filepath.Join(s.config.Sync.Cache.Dir, profileID+".json")
+}
+
+// readCacheInfo 캐시 메타데이터 읽기
+func (s *SyncEngine) readCacheInfo(profileID string) (*CacheInfo, error) {
+	data, err := os.ReadFile(s.cacheInfoPath(profileID))
+	if err != nil {
+		return nil, err
+	}
+
+	var info CacheInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("캐시 메타데이터 파싱 실패: %w", err)
+	}
+	return &info, nil
+}
+
+// writeCacheInfo 캐시 메타데이터 저장
+func (s *SyncEngine) writeCacheInfo(profile *config.SyncProfile, info *CacheInfo) error {
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("캐시 메타데이터 마샬링 실패: %w", err)
+	}
+	if err := os.WriteFile(s.cacheInfoPath(profile.ID), data, 0644); err != nil {
+		return fmt.Errorf("캐시 메타데이터 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// removeCache 캐시 데이터와 메타데이터 삭제
+func (s *SyncEngine) removeCache(profileID string) error {
+	if err := os.RemoveAll(filepath.Join(s.config.Sync.Cache.Dir, profileID)); err != nil {
+		return fmt.Errorf("캐시 삭제 실패: %w", err)
+	}
+	if err := os.Remove(s.cacheInfoPath(profileID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("캐시 메타데이터 삭제 실패: %w", err)
+	}
+	return nil
+}
+
+// DirSize 디렉토리 전체 파일 크기 합계
+func DirSize(root string) (int64, error) {
+	var total int64
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
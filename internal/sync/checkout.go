@@ -0,0 +1,204 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+	"sync-tool/internal/manifest"
+)
+
+// 체크아웃 동작
+const (
+	CheckoutCopy   = "copy"   // 캐시나 백업에서 내용을 복사
+	CheckoutDelete = "delete" // 매니페스트에 없는 파일 삭제
+)
+
+// CheckoutStep 대상을 매니페스트 상태로 되돌리는 계획의 한 단계
+type CheckoutStep struct {
+	Action string
+	Path   string // 대상 기준 상대 경로 (슬래시 구분)
+	Source string // 복사할 내용의 경로 (캐시 또는 백업)
+	Entry  manifest.Entry
+}
+
+// PlanCheckout 대상을 매니페스트와 똑같은 상태로 만드는 계획 (캐시와 백업에서 내용을 찾지 못한 파일은 missing)
+// 내용은 수정 시각이 아니라 해시로 비교하고, 필터나 .syncignore로 제외되거나 보호된 파일은 삭제하지 않음
+func (s *SyncEngine) PlanCheckout(profile *config.SyncProfile, m *manifest.Manifest) ([]CheckoutStep, []string, error) {
+	steps := []CheckoutStep{}
+	missing := []string{}
+
+	for _, entry := range m.Entries {
+		target := filepath.Join(profile.LocalPath, filepath.FromSlash(entry.Path))
+		if manifest.Verify(target, entry) {
+			continue
+		}
+		source, ok := s.findContent(profile, entry)
+		if !ok {
+			missing = append(missing, entry.Path)
+			continue
+		}
+		steps = append(steps, CheckoutStep{Action: CheckoutCopy, Path: entry.Path, Source: source, Entry: entry})
+	}
+
+	current, err := manifest.Files(profile.LocalPath, s.ArtifactDirs(profile))
+	if err != nil {
+		return nil, nil, err
+	}
+	index := m.Index()
+	rules := s.FilterRules(profile)
+	filters := NewFilterSet(rules, profile.LocalPath)
+	for _, path := range current {
+		if _, exists := index[path]; exists {
+			continue
+		}
+		if !filters.Decide(path, false).Included || protectedByFilter(rules, path) {
+			logger.Infof("필터로 제외되거나 보호되어 삭제하지 않음: %s", path)
+			continue
+		}
+		steps = append(steps, CheckoutStep{Action: CheckoutDelete, Path: path})
+	}
+
+	return steps, missing, nil
+}
+
+// ApplyCheckout 체크아웃 계획 적용 (백업을 사용하면 교체/삭제되는 파일은 백업 회차에 보관)
+func (s *SyncEngine) ApplyCheckout(ctx context.Context, profile *config.SyncProfile, steps []CheckoutStep) error {
+	s.startBackup(profile)
+	defer func() { s.backupDir = "" }()
+
+	for _, step := range steps {
+		if ctx.Err() != nil {
+			return fmt.Errorf("체크아웃 중단: %w", ctx.Err())
+		}
+		rel := filepath.FromSlash(step.Path)
+		target := filepath.Join(profile.LocalPath, rel)
+
+		switch step.Action {
+		case CheckoutCopy:
+			// replaceFile은 임시 파일을 rename하므로 하드 링크된 백업은 바뀌지 않음
+			if err := s.backupCopy(target, rel); err != nil {
+				return fmt.Errorf("백업 실패: %s: %w", step.Path, err)
+			}
+			if err := replaceFile(ctx, step.Source, target, step.Entry); err != nil {
+				return err
+			}
+			fmt.Fprintf(s.output, "📄 %s\n", step.Path)
+		case CheckoutDelete:
+			remove := os.Remove
+			if s.backupDir != "" {
+				remove = func(path string) error { return s.backupMove(path, rel) }
+			}
+			if err := remove(target); err != nil {
+				return fmt.Errorf("파일 삭제 실패: %s: %w", step.Path, err)
+			}
+			fmt.Fprintf(s.output, "🗑️  %s\n", step.Path)
+		}
+	}
+
+	if s.backupDir != "" {
+		if err := s.PruneBackups(profile); err != nil {
+			logger.Warnf("백업 정리 실패: %v", err)
+		}
+	}
+	return nil
+}
+
+// SelectPaths 동기화 계획에서 지정한 파일이나 디렉토리 아래의 복사 항목만 남김 (삭제는 모두 제외)
+// 경로는 대상 기준 상대 경로(슬래시 구분)이며, 복사할 항목이 하나도 없는 경로는 unmatched로 반환
+func SelectPaths(plan *SyncResult, paths []string) (*SyncResult, []string) {
+	selected := &SyncResult{
+		Changes:   []FileChange{},
+		Deletions: []string{},
+	}
+	matched := make(map[string]bool, len(paths))
+
+	for _, change := range plan.Changes {
+		path := strings.TrimSuffix(change.Path, "/")
+		for _, want := range paths {
+			if path == want || strings.HasPrefix(path, want+"/") {
+				selected.Changes = append(selected.Changes, change)
+				matched[want] = true
+				break
+			}
+		}
+	}
+
+	unmatched := []string{}
+	for _, want := range paths {
+		if !matched[want] {
+			unmatched = append(unmatched, want)
+		}
+	}
+
+	// 전송 크기는 계획 전체 기준이므로 일부만 고르면 알 수 없음
+	selected.HasChanges = len(selected.Changes) > 0
+	return selected, unmatched
+}
+
+// findContent 매니페스트 항목과 같은 내용의 파일을 캐시와 백업(최신순)에서 찾기
+func (s *SyncEngine) findContent(profile *config.SyncProfile, entry manifest.Entry) (string, bool) {
+	rel := filepath.FromSlash(entry.Path)
+	candidates := []string{}
+	if cacheDir, ok := s.CacheDir(profile); ok {
+		candidates = append(candidates, filepath.Join(cacheDir, rel))
+	}
+	if versions, err := s.BackupVersions(profile, rel); err == nil {
+		for i := len(versions) - 1; i >= 0; i-- {
+			candidates = append(candidates, versions[i].Path)
+		}
+	}
+
+	for _, candidate := range candidates {
+		if manifest.Verify(candidate, entry) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// replaceFile 임시 파일에 복사한 뒤 rename으로 교체하고 매니페스트의 수정 시각 적용
+func replaceFile(ctx context.Context, source, target string, entry manifest.Entry) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return fmt.Errorf("원본 확인 실패: %w", err)
+	}
+
+	tmp := target + ".sync-tmp"
+	if err := copyFile(ctx, source, tmp, info); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	// 계획 이후 원본이 바뀌었거나 복사 중 손상된 내용으로 교체하지 않음
+	if !manifest.Verify(tmp, entry) {
+		os.Remove(tmp)
+		return fmt.Errorf("복사한 내용의 해시가 태그와 다릅니다: %s", target)
+	}
+	if err := os.Chtimes(tmp, entry.ModTime, entry.ModTime); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("수정 시각 설정 실패: %w", err)
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("파일 교체 실패: %s: %w", target, err)
+	}
+	return nil
+}
+
+// protectedByFilter P(protect) 규칙으로 삭제에서 보호되는 경로인지 확인 (상위 디렉토리 포함)
+func protectedByFilter(rules []FilterRule, relPath string) bool {
+	parts := strings.Split(relPath, "/")
+	for i := range parts {
+		current := strings.Join(parts[:i+1], "/")
+		for j := range rules {
+			if rules[j].Action == FilterProtect && rules[j].matches(current, i < len(parts)-1) {
+				return true
+			}
+		}
+	}
+	return false
+}
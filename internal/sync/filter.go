@@ -0,0 +1,350 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+)
+
+// FilterAction 필터 규칙 동작
+type FilterAction string
+
+const (
+	FilterInclude  FilterAction = "+"
+	FilterExclude  FilterAction = "-"
+	FilterProtect  FilterAction = "P"
+	FilterRisk     FilterAction = "R"
+	FilterHide     FilterAction = "H"
+	FilterShow     FilterAction = "S"
+	FilterMerge    FilterAction = "."
+	FilterDirMerge FilterAction = ":"
+)
+
+// SyncIgnoreFile 디렉토리별 제외 패턴 파일 이름 (서버 트리와 로컬 트리 모두에서 읽음)
+const SyncIgnoreFile = ".syncignore"
+
+// filterLongNames rsync 긴 형식 규칙 이름
+var filterLongNames = map[string]FilterAction{
+	"include":   FilterInclude,
+	"exclude":   FilterExclude,
+	"protect":   FilterProtect,
+	"risk":      FilterRisk,
+	"hide":      FilterHide,
+	"show":      FilterShow,
+	"merge":     FilterMerge,
+	"dir-merge": FilterDirMerge,
+}
+
+// FilterRule rsync 필터 규칙 하나
+type FilterRule struct {
+	Action    FilterAction
+	Modifiers string
+	Pattern   string
+	Source    string // 규칙 출처 (예: "default_excludes[0]")
+	Base      string // dir-merge 파일에서 읽은 규칙이면 그 파일이 있는 디렉토리 (루트 기준)
+}
+
+// String rsync --filter 형식 문자열
+func (r FilterRule) String() string {
+	return string(r.Action) + r.Modifiers + " " + r.Pattern
+}
+
+// ParseFilterRule "+ pattern", "- pattern", "P pattern", ". file", ":- .file" 또는
+// "exclude,! pattern" 같은 rsync 필터 규칙 문자열 파싱
+func ParseFilterRule(text string) (FilterRule, error) {
+	text = strings.TrimSpace(text)
+
+	sep := strings.IndexByte(text, ' ')
+	if sep <= 0 {
+		return FilterRule{}, fmt.Errorf("잘못된 필터 규칙: %q (\"<규칙> <패턴>\" 형식이어야 합니다)", text)
+	}
+	head, pattern := text[:sep], strings.TrimSpace(text[sep+1:])
+	if pattern == "" {
+		return FilterRule{}, fmt.Errorf("필터 규칙에 패턴이 없습니다: %q", text)
+	}
+
+	name, modifiers := head, ""
+	if i := strings.IndexByte(head, ','); i >= 0 {
+		name, modifiers = head[:i], head[i+1:]
+	}
+	if action, ok := filterLongNames[name]; ok {
+		return FilterRule{Action: action, Modifiers: modifiers, Pattern: pattern}, nil
+	}
+
+	action := FilterAction(head[:1])
+	for _, known := range filterLongNames {
+		if action == known {
+			return FilterRule{Action: action, Modifiers: strings.TrimPrefix(head[1:], ","), Pattern: pattern}, nil
+		}
+	}
+	return FilterRule{}, fmt.Errorf("알 수 없는 필터 규칙: %q", text)
+}
+
+// FilterRules 프로필에 적용되는 필터 규칙을 rsync에 전달되는 순서대로 반환
+//
+// 우선순위 (먼저 일치하는 규칙이 적용됨):
+//  1. sync.default_excludes
+//  2. 트리 안의 .syncignore 파일 (디렉토리별 제외 패턴)
+//  3. 프로필 filters (작성 순서대로)
+//  4. 프로필 excludes (기존 필드)
+//  5. 프로필 includes (기존 필드, 이전 버전과 같이 excludes 다음)
+//
+// merge(".") 규칙은 파일을 읽을 수 있으면 그 내용으로 펼쳐서 반환합니다.
+func (s *SyncEngine) FilterRules(profile *config.SyncProfile) []FilterRule {
+	rules := []FilterRule{}
+
+	for i, pattern := range s.config.Sync.DefaultExcludes {
+		rules = append(rules, FilterRule{Action: FilterExclude, Pattern: pattern, Source: indexedSource("default_excludes", i)})
+	}
+	rules = append(rules, syncIgnoreRule())
+	for i, text := range profile.Filters {
+		rule, err := ParseFilterRule(text)
+		if err != nil {
+			logger.Warnf("필터 규칙 무시: %v", err)
+			continue
+		}
+		rule.Source = indexedSource("filters", i)
+		rules = append(rules, expandMergeRule(rule)...)
+	}
+	for i, pattern := range profile.Excludes {
+		rules = append(rules, FilterRule{Action: FilterExclude, Pattern: pattern, Source: indexedSource("excludes", i)})
+	}
+	for i, pattern := range profile.Includes {
+		rules = append(rules, FilterRule{Action: FilterInclude, Pattern: pattern, Source: indexedSource("includes", i)})
+	}
+
+	return rules
+}
+
+// syncIgnoreRule .syncignore 파일을 디렉토리별 제외 목록으로 병합하는 규칙
+// ("e" 수식어로 .syncignore 자체는 전송하지 않으므로 로컬에서 만든 파일도 삭제되지 않음)
+func syncIgnoreRule() FilterRule {
+	return FilterRule{Action: FilterDirMerge, Modifiers: "-e", Pattern: SyncIgnoreFile, Source: "syncignore"}
+}
+
+// validateFilters 프로필 필터 규칙 문법 검사
+func validateFilters(profile *config.SyncProfile) error {
+	for i, text := range profile.Filters {
+		if _, err := ParseFilterRule(text); err != nil {
+			return fmt.Errorf("filters[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// expandMergeRule merge 규칙이면 파일 내용을 규칙 목록으로 펼침 (읽을 수 없으면 그대로 유지)
+func expandMergeRule(rule FilterRule) []FilterRule {
+	if rule.Action != FilterMerge {
+		return []FilterRule{rule}
+	}
+
+	data, err := os.ReadFile(config.ExpandHome(rule.Pattern))
+	if err != nil {
+		logger.Warnf("merge 파일 읽기 실패, rsync에 그대로 전달: %s", rule.Pattern)
+		return []FilterRule{rule}
+	}
+
+	source := fmt.Sprintf("%s → %s", rule.Source, rule.Pattern)
+	return parseRuleLines(string(data), rule.Modifiers, source)
+}
+
+// parseRuleLines merge 파일 내용을 규칙 목록으로 변환
+// modifiers에 "-" 또는 "+"가 있으면 모든 줄을 제외/포함 패턴으로 취급
+func parseRuleLines(content string, modifiers string, source string) []FilterRule {
+	rules := []FilterRule{}
+
+	for n, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+
+		lineSource := fmt.Sprintf("%s:%d", source, n+1)
+		switch {
+		case trimmed == "!" || (strings.HasPrefix(trimmed, "!") && (strings.Contains(modifiers, "-") || strings.Contains(modifiers, "+"))):
+			// rsync에서 "!" 줄은 규칙 목록 초기화이며 gitignore식 부정 패턴은 지원하지 않음
+			logger.Warnf("%s: \"!\" 패턴은 지원하지 않아 무시합니다: %s", lineSource, trimmed)
+		case strings.Contains(modifiers, "-"):
+			rules = append(rules, FilterRule{Action: FilterExclude, Pattern: trimmed, Source: lineSource})
+		case strings.Contains(modifiers, "+"):
+			rules = append(rules, FilterRule{Action: FilterInclude, Pattern: trimmed, Source: lineSource})
+		default:
+			rule, err := ParseFilterRule(trimmed)
+			if err != nil {
+				logger.Warnf("%s: %v", lineSource, err)
+				continue
+			}
+			rule.Source = lineSource
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// MatchFilters 전송 여부를 결정하는 규칙 중 경로에 처음 일치하는 규칙 반환 (일치하는 규칙이 없으면 nil)
+func MatchFilters(rules []FilterRule, relPath string, isDir bool) *FilterRule {
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.affectsTransfer() {
+			continue
+		}
+		if rule.matches(relPath, isDir) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// IsIncluded 경로가 전송 대상인지 확인 (상위 디렉토리가 제외되면 하위도 제외)
+func IsIncluded(rules []FilterRule, relPath string, isDir bool) bool {
+	return NewFilterSet(rules, "").Decide(relPath, isDir).Included
+}
+
+// affectsTransfer 송신 측 전송 여부에 영향을 주는 규칙인지 확인
+func (r *FilterRule) affectsTransfer() bool {
+	switch r.Action {
+	case FilterInclude, FilterExclude:
+		return !strings.Contains(r.Modifiers, "r")
+	case FilterHide, FilterShow:
+		return true
+	}
+	return false
+}
+
+// excludes 일치 시 제외하는 규칙인지 확인
+func (r *FilterRule) excludes() bool {
+	return r.Action == FilterExclude || r.Action == FilterHide
+}
+
+// matches 규칙 패턴 일치 여부 ("!" 수식어는 결과를 반전)
+func (r *FilterRule) matches(relPath string, isDir bool) bool {
+	// dir-merge 규칙은 해당 디렉토리 하위 경로에만, 그 디렉토리 기준으로 비교
+	if r.Base != "" {
+		if !strings.HasPrefix(relPath, r.Base+"/") {
+			return false
+		}
+		relPath = strings.TrimPrefix(relPath, r.Base+"/")
+	}
+
+	matched := MatchPattern(r.Pattern, relPath, isDir)
+	if strings.Contains(r.Modifiers, "!") {
+		return !matched
+	}
+	return matched
+}
+
+// MatchPattern rsync 패턴 규칙에 따라 전송 루트 기준 상대 경로가 패턴과 일치하는지 확인
+//
+// - 끝의 "/"는 디렉토리에만 일치
+// - 앞의 "/"는 전송 루트에 고정
+// - "/"나 "**"가 없는 패턴은 마지막 경로 요소에만 비교
+// - "dir/***"는 dir 자체와 그 하위 전체에 일치
+func MatchPattern(pattern string, relPath string, isDir bool) bool {
+	relPath = strings.Trim(relPath, "/")
+	if pattern == "" || relPath == "" {
+		return false
+	}
+
+	if strings.HasSuffix(pattern, "/") {
+		if !isDir {
+			return false
+		}
+		pattern = strings.TrimRight(pattern, "/")
+	}
+
+	anchored := strings.HasPrefix(pattern, "/")
+	pattern = strings.TrimLeft(pattern, "/")
+
+	if strings.HasSuffix(pattern, "/***") {
+		base := strings.TrimSuffix(pattern, "/***")
+		if anchored {
+			base = "/" + base
+		}
+		return MatchPattern(base, relPath, true) || MatchPattern(base+"/**", relPath, isDir)
+	}
+
+	re := patternRegexp(pattern)
+	if re == nil {
+		return false
+	}
+
+	// 경로 요소만 비교
+	if !anchored && !strings.Contains(pattern, "/") && !strings.Contains(pattern, "**") {
+		return re.MatchString(path.Base(relPath))
+	}
+
+	if re.MatchString(relPath) {
+		return true
+	}
+	if anchored {
+		return false
+	}
+
+	// 고정되지 않은 패턴은 디렉토리 경계 뒤의 어느 위치에서든 일치 가능
+	for i := 0; i < len(relPath); i++ {
+		if relPath[i] == '/' && re.MatchString(relPath[i+1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// patternRegexp rsync 와일드카드 패턴을 정규식으로 변환
+func patternRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				b.WriteString(".*")
+				for i+1 < len(pattern) && pattern[i+1] == '*' {
+					i++
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// indexedSource 규칙 출처 문자열 생성
+func indexedSource(name string, index int) string {
+	return fmt.Sprintf("%s[%d]", name, index)
+}
@@ -0,0 +1,156 @@
+package sync
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FilterDecision 경로 하나에 대한 필터 판정 결과
+type FilterDecision struct {
+	Path     string
+	IsDir    bool
+	Included bool
+	Rule     *FilterRule // 판정을 결정한 규칙 (nil이면 일치하는 규칙 없음)
+	Parent   string      // 상위 디렉토리 제외로 결정된 경우 그 디렉토리
+}
+
+// FilterSet 필터 규칙 평가기 (root가 있으면 트리 안의 dir-merge 파일도 읽음)
+type FilterSet struct {
+	rules  []FilterRule
+	root   string
+	merged map[string][]FilterRule
+}
+
+// NewFilterSet 새로운 필터 평가기 생성 (root가 ""이면 dir-merge 규칙은 무시)
+func NewFilterSet(rules []FilterRule, root string) *FilterSet {
+	return &FilterSet{
+		rules:  rules,
+		root:   root,
+		merged: make(map[string][]FilterRule),
+	}
+}
+
+// Decide 경로의 전송 여부와 그 이유 판정 (rsync처럼 상위 디렉토리부터 확인)
+func (f *FilterSet) Decide(relPath string, isDir bool) FilterDecision {
+	relPath = strings.Trim(filepath.ToSlash(relPath), "/")
+	decision := FilterDecision{Path: relPath, IsDir: isDir, Included: true}
+
+	parts := strings.Split(relPath, "/")
+	for i := range parts {
+		current := strings.Join(parts[:i+1], "/")
+		currentIsDir := isDir || i < len(parts)-1
+
+		rule := MatchFilters(f.rulesFor(path.Dir(current)), current, currentIsDir)
+		if rule == nil {
+			continue
+		}
+
+		if current == relPath {
+			decision.Rule = rule
+			decision.Included = !rule.excludes()
+			return decision
+		}
+		if rule.excludes() {
+			decision.Rule = rule
+			decision.Included = false
+			decision.Parent = current
+			return decision
+		}
+	}
+	return decision
+}
+
+// Walk 루트 트리를 순회하며 각 경로의 판정 결과 전달 (제외된 디렉토리는 하위로 내려가지 않음)
+func (f *FilterSet) Walk(fn func(decision FilterDecision) error) error {
+	if f.root == "" {
+		return fmt.Errorf("순회할 루트 디렉토리가 지정되지 않았습니다")
+	}
+
+	return filepath.WalkDir(f.root, func(fullPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(f.root, fullPath)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		decision := f.Decide(rel, entry.IsDir())
+		if err := fn(decision); err != nil {
+			return err
+		}
+		if entry.IsDir() && !decision.Included {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
+
+// rulesFor dir 디렉토리 안의 경로에 적용되는 규칙 목록
+// dir-merge 규칙 자리에 해당 디렉토리부터 루트까지의 병합 파일 규칙을 (하위 디렉토리 우선으로) 끼워 넣음
+func (f *FilterSet) rulesFor(dir string) []FilterRule {
+	if dir == "." {
+		dir = ""
+	}
+
+	rules := make([]FilterRule, 0, len(f.rules))
+	for _, rule := range f.rules {
+		if rule.Action != FilterDirMerge {
+			rules = append(rules, rule)
+			continue
+		}
+		// "e" 수식어: 병합 파일 자체는 전송하지 않음
+		if strings.Contains(rule.Modifiers, "e") {
+			rules = append(rules, FilterRule{Action: FilterExclude, Pattern: path.Base(rule.Pattern), Source: rule.Source})
+		}
+		if f.root == "" {
+			continue
+		}
+
+		for current := dir; ; current = parentDir(current) {
+			if current == dir || !strings.Contains(rule.Modifiers, "n") {
+				rules = append(rules, f.mergeFile(rule, current)...)
+			}
+			if current == "" {
+				break
+			}
+		}
+	}
+	return rules
+}
+
+// mergeFile dir 디렉토리의 dir-merge 파일 규칙 읽기 (결과 캐시)
+func (f *FilterSet) mergeFile(rule FilterRule, dir string) []FilterRule {
+	key := rule.Pattern + "\x00" + dir
+	if rules, ok := f.merged[key]; ok {
+		return rules
+	}
+
+	filePath := filepath.Join(f.root, filepath.FromSlash(dir), strings.TrimPrefix(rule.Pattern, "/"))
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		f.merged[key] = nil
+		return nil
+	}
+
+	display := path.Join(dir, strings.TrimPrefix(rule.Pattern, "/"))
+	rules := parseRuleLines(string(data), rule.Modifiers, display)
+	for i := range rules {
+		rules[i].Base = dir
+	}
+	f.merged[key] = rules
+	return rules
+}
+
+// parentDir 루트 기준 상위 디렉토리 ("" 이 루트)
+func parentDir(dir string) string {
+	parent := path.Dir(dir)
+	if parent == "." || parent == "/" {
+		return ""
+	}
+	return parent
+}
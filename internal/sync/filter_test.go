@@ -0,0 +1,79 @@
+package sync
+
+import "testing"
+
+func TestParseFilterRule(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    FilterRule
+		wantErr bool
+	}{
+		{"+ *.iso", FilterRule{Action: FilterInclude, Pattern: "*.iso"}, false},
+		{"- /tmp/", FilterRule{Action: FilterExclude, Pattern: "/tmp/"}, false},
+		{"P keep/", FilterRule{Action: FilterProtect, Pattern: "keep/"}, false},
+		{"  -   spaced name  ", FilterRule{Action: FilterExclude, Pattern: "spaced name"}, false},
+		{"exclude,! *.txt", FilterRule{Action: FilterExclude, Modifiers: "!", Pattern: "*.txt"}, false},
+		{"dir-merge .syncignore", FilterRule{Action: FilterDirMerge, Pattern: ".syncignore"}, false},
+		{":- .syncignore", FilterRule{Action: FilterDirMerge, Modifiers: "-", Pattern: ".syncignore"}, false},
+		{"-,s secret", FilterRule{Action: FilterExclude, Modifiers: "s", Pattern: "secret"}, false},
+		{". rules.txt", FilterRule{Action: FilterMerge, Pattern: "rules.txt"}, false},
+		{"", FilterRule{}, true},
+		{"+", FilterRule{}, true},
+		{"+*.iso", FilterRule{}, true},
+		{"include ", FilterRule{}, true},
+		{"X foo", FilterRule{}, true},
+		{"ignore foo", FilterRule{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFilterRule(tt.text)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFilterRule(%q) 오류 = %v, 오류 기대 = %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFilterRule(%q) = %+v, 기대값 %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{"*.iso", "x.iso", false, true},
+		{"*.iso", "a/b/x.iso", false, true},
+		{"*.iso", "x.iso.part", false, false},
+		{"tmp/", "tmp", true, true},
+		{"tmp/", "tmp", false, false},
+		{"tmp/", "a/tmp", true, true},
+		{"/tmp", "tmp", false, true},
+		{"/tmp", "a/tmp", false, false},
+		{"a/*.txt", "a/b.txt", false, true},
+		{"a/*.txt", "x/a/b.txt", false, true},
+		{"a/*.txt", "a/b/c.txt", false, false},
+		{"/a/*.txt", "x/a/b.txt", false, false},
+		{"a/**", "a/b/c.txt", false, true},
+		{"dir/***", "dir", true, true},
+		{"dir/***", "dir/x/y", false, true},
+		{"dir/***", "dirx", true, false},
+		{"file?.txt", "file1.txt", false, true},
+		{"file?.txt", "file10.txt", false, false},
+		{"[ab].txt", "a.txt", false, true},
+		{"[!ab].txt", "a.txt", false, false},
+		{"[!ab].txt", "c.txt", false, true},
+		{`\*.txt`, "*.txt", false, true},
+		{`\*.txt`, "a.txt", false, false},
+		{"", "a", false, false},
+		{"a", "", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchPattern(tt.pattern, tt.path, tt.isDir); got != tt.want {
+			t.Errorf("MatchPattern(%q, %q, %v) = %v, 기대값 %v", tt.pattern, tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package sync
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"sync-tool/internal/logger"
+	"sync-tool/internal/state"
+)
+
+// JournalPlan 동기화 계획을 저널 형식으로 변환
+func JournalPlan(profileID, target string, changes *SyncResult) state.JournalPlan {
+	plan := state.JournalPlan{
+		Profile:   profileID,
+		Target:    target,
+		Changes:   make([]state.JournalChange, 0, len(changes.Changes)),
+		Deletions: append([]string{}, changes.Deletions...),
+		Bytes:     changes.TotalBytes,
+	}
+	for _, change := range changes.Changes {
+		plan.Changes = append(plan.Changes, state.JournalChange{Type: string(change.Type), Path: change.Path})
+	}
+	return plan
+}
+
+// ResumeResult 저널에서 아직 끝나지 않은 항목으로 동기화 계획 구성
+func ResumeResult(journal *state.Journal) *SyncResult {
+	changes, deletions := journal.Remaining()
+
+	result := &SyncResult{
+		Changes:   make([]FileChange, 0, len(changes)),
+		Deletions: deletions,
+	}
+	for _, change := range changes {
+		result.Changes = append(result.Changes, FileChange{Type: ChangeType(change.Type), Path: change.Path})
+	}
+	result.HasChanges = len(result.Changes) > 0
+	result.HasDeletions = len(result.Deletions) > 0
+	return result
+}
+
+// markCompleted 저널에 복사 완료 기록
+func (s *SyncEngine) markCompleted(path string) {
+	if s.journal == nil {
+		return
+	}
+	if err := s.journal.MarkCompleted(path); err != nil {
+		logger.Warnf("저널 기록 실패: %v", err)
+	}
+}
+
+// markDeleted 저널에 삭제 완료 기록
+func (s *SyncEngine) markDeleted(path string) {
+	if s.journal == nil {
+		return
+	}
+	if err := s.journal.MarkDeleted(path); err != nil {
+		logger.Warnf("저널 기록 실패: %v", err)
+	}
+}
+
+// itemizeTracker rsync 출력을 그대로 전달하면서 --itemize-changes 줄로 완료된 파일 추적
+// rsync는 파일을 하나씩 받으므로 다음 파일 줄이 나오면 이전 파일은 완료된 것으로 봄
+type itemizeTracker struct {
+	out     io.Writer
+	done    func(path string)
+	buf     bytes.Buffer
+	current string
+}
+
+// newItemizeTracker 새로운 출력 추적기 생성
+func newItemizeTracker(out io.Writer, done func(path string)) *itemizeTracker {
+	return &itemizeTracker{out: out, done: done}
+}
+
+// Write 출력을 전달하고 완성된 줄 검사
+func (t *itemizeTracker) Write(p []byte) (int, error) {
+	if _, err := t.out.Write(p); err != nil {
+		return 0, err
+	}
+
+	t.buf.Write(p)
+	for {
+		line, err := t.buf.ReadString('\n')
+		if err != nil {
+			t.buf.WriteString(line)
+			break
+		}
+		if path, ok := itemizedFile(line); ok {
+			if t.current != "" {
+				t.done(t.current)
+			}
+			t.current = path
+		}
+	}
+	return len(p), nil
+}
+
+// Finish rsync가 성공적으로 끝났을 때 마지막 파일까지 완료 처리
+func (t *itemizeTracker) Finish() {
+	if path, ok := itemizedFile(t.buf.String()); ok {
+		if t.current != "" {
+			t.done(t.current)
+		}
+		t.current = path
+	}
+	if t.current != "" {
+		t.done(t.current)
+		t.current = ""
+	}
+}
+
+// itemizedFile 파일을 받은 itemize 줄이면 경로 반환 (예: ">f+++++++++ iso/a.iso")
+func itemizedFile(line string) (string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || len(fields[0]) < 9 {
+		return "", false
+	}
+	code := fields[0]
+	if (code[0] != '>' && code[0] != 'c') || code[1] != 'f' {
+		return "", false
+	}
+	// 드라이런 계획과 같은 방식으로 마지막 필드를 경로로 사용
+	return fields[len(fields)-1], true
+}
@@ -0,0 +1,138 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+)
+
+// RsyncError 종료 코드가 포함된 rsync 실행 오류
+type RsyncError struct {
+	ExitCode int
+}
+
+// Error 종료 코드에 따른 오류 메시지
+func (e *RsyncError) Error() string {
+	switch e.ExitCode {
+	case 23:
+		return "rsync 부분 실패 (일부 파일 전송 실패)"
+	case 24:
+		return "rsync 일시적 실패 (재시도 필요)"
+	default:
+		return fmt.Sprintf("rsync 실행 실패 (exit code %d)", e.ExitCode)
+	}
+}
+
+// syncWithRetry 재시도 정책에 따라 파일을 복사하고, 재시도할 때는 완료되지 않은 파일만 다시 계획
+func (s *SyncEngine) syncWithRetry(ctx context.Context, profile *config.SyncProfile, changes *SyncResult) error {
+	retry := s.transfer(profile).Retry
+	pending := changes.Changes
+	done := func(path string) {
+		s.markCompleted(path)
+		changes.Copied++
+	}
+	if profile.Staging {
+		// 스테이징에 받은 파일은 제자리로 옮길 때 완료로 기록
+		done = func(string) {}
+	}
+
+	for attempt := 1; ; attempt++ {
+		changes.Attempts = attempt
+
+		err := s.syncFiles(ctx, profile, pending, done)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		var rsyncErr *RsyncError
+		if !errors.As(err, &rsyncErr) || !retryableCode(rsyncErr.ExitCode, retry.ExitCodes) || attempt >= retry.MaxAttempts {
+			return err
+		}
+
+		delay := retryDelay(retry, attempt)
+		logger.WithFields(logger.Fields{
+			"profile":   profile.ID,
+			"attempt":   attempt,
+			"exit_code": rsyncErr.ExitCode,
+			"pending":   len(pending),
+			"retry_in":  delay.String(),
+		}).Warnf("파일 복사 실패, 재시도 예정: %v", err)
+		fmt.Fprintf(s.output, "⚠️  %v. %v 후 재시도합니다 (%d/%d)\n", err, delay, attempt+1, retry.MaxAttempts)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("재시도 대기 중 중단: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+
+		// 완료된 파일은 제외하고 남은 파일만 다시 전송
+		remaining, planErr := s.remainingChanges(ctx, profile, pending)
+		if planErr != nil {
+			if ctx.Err() != nil {
+				return planErr
+			}
+			logger.Warnf("재시도 계획 실패, 이전 목록으로 재시도: %v", planErr)
+			continue
+		}
+		if len(remaining) == 0 {
+			logger.Info("재시도 계획 결과 남은 파일이 없습니다")
+			return nil
+		}
+		logger.Infof("남은 파일 %d개 재전송 (%d개 완료됨)", len(remaining), len(pending)-len(remaining))
+		pending = remaining
+	}
+}
+
+// remainingChanges 드라이런으로 pending 중 아직 전송되지 않은 파일만 반환
+func (s *SyncEngine) remainingChanges(ctx context.Context, profile *config.SyncProfile, pending []FileChange) ([]FileChange, error) {
+	plan, err := s.DryRun(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(pending))
+	for _, change := range pending {
+		wanted[change.Path] = true
+	}
+
+	remaining := []FileChange{}
+	for _, change := range plan.Changes {
+		if wanted[change.Path] {
+			remaining = append(remaining, change)
+		}
+	}
+	return remaining, nil
+}
+
+// retryableCode 재시도할 종료 코드인지 확인
+func retryableCode(code int, codes []int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// retryDelay attempt번째 실패 후 대기 시간 (두 배씩 증가, 최대 max_backoff)
+func retryDelay(retry config.RetryConfig, attempt int) time.Duration {
+	delay, err := config.ParseDuration(retry.Backoff)
+	if err != nil || delay <= 0 {
+		delay, _ = config.ParseDuration(config.DefaultRetryBackoff)
+	}
+	maxDelay, err := config.ParseDuration(retry.MaxBackoff)
+	if err != nil || maxDelay <= 0 {
+		maxDelay, _ = config.ParseDuration(config.DefaultRetryMaxBackoff)
+	}
+
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
@@ -2,6 +2,8 @@ package sync
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"time"
 )
@@ -12,6 +14,7 @@ type SimpleProgress struct {
 	CurrentFile int
 	StartTime   time.Time
 	LastUpdate  time.Time
+	Output      io.Writer
 }
 
 // NewSimpleProgress 새로운 진행률 표시기 생성
@@ -21,6 +24,7 @@ func NewSimpleProgress(totalFiles int) *SimpleProgress {
 		CurrentFile: 0,
 		StartTime:   time.Now(),
 		LastUpdate:  time.Now(),
+		Output:      os.Stdout,
 	}
 }
 
@@ -57,18 +61,18 @@ func (p *SimpleProgress) Update(currentFile int, filename string) {
 	}
 
 	// 진행률 출력
-	fmt.Printf("\r📊 [%d/%d] %s |%s| %.1f%% (%v)",
+	fmt.Fprintf(p.Output, "\r📊 [%d/%d] %s |%s| %.1f%% (%v)",
 		currentFile, p.TotalFiles, displayName, bar, percent, elapsed.Round(time.Second))
 
 	// 완료 시 새 줄
 	if currentFile >= p.TotalFiles {
-		fmt.Println()
+		fmt.Fprintln(p.Output)
 	}
 }
 
 // Complete 완료 메시지
 func (p *SimpleProgress) Complete() {
 	totalTime := time.Since(p.StartTime)
-	fmt.Printf("✅ 동기화 완료! 총 %d개 파일, 소요 시간: %v\n",
+	fmt.Fprintf(p.Output, "✅ 동기화 완료! 총 %d개 파일, 소요 시간: %v\n",
 		p.TotalFiles, totalTime.Round(time.Second))
 }
@@ -0,0 +1,169 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/device"
+	"sync-tool/internal/logger"
+)
+
+// StagingDir 스테이징 모드에서 새 파일을 먼저 받는 대상 경로 아래의 숨김 디렉토리
+const StagingDir = ".sync-staging"
+
+// stagingPath 프로필 대상의 스테이징 디렉토리 (같은 볼륨이어야 rename이 원자적)
+func (s *SyncEngine) stagingPath(profile *config.SyncProfile) string {
+	return filepath.Join(profile.LocalPath, StagingDir)
+}
+
+// commitStaged 스테이징에 모든 파일이 온전히 받아졌는지 확인하고 디스크에 기록한 뒤 한 번에 제자리로 이동
+// 이동 단계는 짧은 rename만 수행하므로 중간에 USB를 뽑아도 섞인 상태가 남을 가능성이 작음
+func (s *SyncEngine) commitStaged(ctx context.Context, profile *config.SyncProfile, changes *SyncResult) error {
+	staging := s.stagingPath(profile)
+
+	// 1. 확인: 계획된 파일이 모두 스테이징에 있어야 함
+	missing := []string{}
+	for _, change := range changes.Changes {
+		if _, err := os.Lstat(filepath.Join(staging, change.Path)); err != nil {
+			missing = append(missing, change.Path)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("스테이징에 전송되지 않은 파일 %d개: %s", len(missing), strings.Join(missing, ", "))
+	}
+
+	// 잘리거나 손상된 파일이 반영되지 않도록 소스와 크기(-c면 체크섬)가 같은지 확인
+	mismatched, err := s.verifyStaged(ctx, profile, changes.Changes)
+	if err != nil {
+		return err
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("스테이징 파일 %d개가 소스와 다릅니다: %s", len(mismatched), strings.Join(mismatched, ", "))
+	}
+
+	// 2. rename 전에 스테이징 데이터를 디스크에 기록
+	fmt.Fprintf(s.output, "💾 스테이징 확인 완료, 디스크에 기록 중: %s\n", staging)
+	if err := device.Flush(staging); err != nil {
+		return fmt.Errorf("스테이징 기록 실패: %w", err)
+	}
+
+	// 3. 제자리로 이동
+	fmt.Fprintf(s.output, "📦 스테이징된 %d개 항목을 반영합니다\n", len(changes.Changes))
+	for _, change := range changes.Changes {
+		source := filepath.Join(staging, change.Path)
+		target := filepath.Join(profile.LocalPath, change.Path)
+
+		info, err := os.Lstat(source)
+		if err != nil {
+			return fmt.Errorf("스테이징 파일 확인 실패: %w", err)
+		}
+
+		// 디렉토리는 만들기만 하고 안의 파일은 각자 이동
+		if info.IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return fmt.Errorf("디렉토리 생성 실패: %w", err)
+			}
+			s.markCompleted(change.Path)
+			changes.Copied++
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("디렉토리 생성 실패: %w", err)
+		}
+		// 교체될 기존 파일은 rename 전에 백업 회차에 링크해 둠 (rename으로 교체하므로 링크된 백업은 바뀌지 않음)
+		if err := s.backupCopy(target, change.Path); err != nil {
+			return fmt.Errorf("백업 실패: %s: %w", change.Path, err)
+		}
+		if err := os.Rename(source, target); err != nil {
+			return fmt.Errorf("스테이징 파일 이동 실패: %s: %w", change.Path, err)
+		}
+		logger.Debugf("스테이징 반영: %s", change.Path)
+		s.markCompleted(change.Path)
+		changes.Copied++
+	}
+
+	if err := os.RemoveAll(staging); err != nil {
+		logger.Warnf("스테이징 디렉토리 삭제 실패: %v", err)
+	}
+	logger.Infof("스테이징 반영 완료: %d개 항목", len(changes.Changes))
+	return nil
+}
+
+// verifyStaged 스테이징된 파일을 소스와 다시 비교하여 다른 파일 목록 반환
+// 동기화 옵션에 -c가 있으면 체크섬, 없으면 크기로 비교 (rsync 드라이런에 나오는 파일이 다른 파일)
+func (s *SyncEngine) verifyStaged(ctx context.Context, profile *config.SyncProfile, changes []FileChange) ([]string, error) {
+	tmpFile, err := os.CreateTemp("", verifyListPattern)
+	if err != nil {
+		return nil, fmt.Errorf("임시 파일 생성 실패: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	count := 0
+	for _, change := range changes {
+		if strings.HasSuffix(change.Path, "/") {
+			continue
+		}
+		fmt.Fprintln(tmpFile, change.Path)
+		count++
+	}
+	tmpFile.Close()
+	if count == 0 {
+		return nil, nil
+	}
+
+	options := profile.GetSyncOptions(s.config.Sync.Options)
+	compare := "--size-only"
+	if hasChecksumOption(options) {
+		compare = "--checksum"
+	}
+
+	source, remote := s.resolveSource(profile)
+	args := []string{}
+	args = append(args, options...)
+	args = append(args, "--dry-run", "--itemize-changes", compare, "--files-from", tmpFile.Name())
+	args = append(args, "--no-perms", "--no-owner", "--no-group")
+	args = append(args, s.transferArgs(profile)...)
+	if remote {
+		args = append(args, "-e", s.sshCommand(profile))
+	}
+	args = append(args, source, s.stagingPath(profile)+"/")
+
+	fmt.Fprintf(s.output, "🔍 스테이징된 파일 %d개를 소스와 비교 중 (%s)\n", count, strings.TrimPrefix(compare, "--"))
+	cmd := s.rsyncCommand(ctx, profile, args)
+	logger.Debugf("스테이징 확인 명령어: %s", strings.Join(cmd.Args, " "))
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("스테이징 확인 중단: %w", ctx.Err())
+	}
+	if err != nil {
+		logger.Errorf("rsync 출력: %s", string(output))
+		return nil, fmt.Errorf("스테이징 확인 실패: %w", err)
+	}
+
+	mismatched := []string{}
+	for _, change := range s.parseRsyncOutput(string(output)).Changes {
+		if !strings.HasSuffix(change.Path, "/") {
+			mismatched = append(mismatched, change.Path)
+		}
+	}
+	return mismatched, nil
+}
+
+// hasChecksumOption rsync 옵션에 -c(--checksum)가 있는지 확인 (-rc처럼 묶인 짧은 옵션 포함)
+func hasChecksumOption(options []string) bool {
+	for _, option := range options {
+		if option == "--checksum" {
+			return true
+		}
+		if strings.HasPrefix(option, "-") && !strings.HasPrefix(option, "--") && strings.Contains(option, "c") {
+			return true
+		}
+	}
+	return false
+}
@@ -1,14 +1,18 @@
 package sync
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"sync-tool/internal/config"
 	"sync-tool/internal/logger"
+	"sync-tool/internal/state"
 )
 
 // ChangeType 파일 변경 타입
@@ -21,6 +25,23 @@ const (
 	ChangeTypeUnchanged ChangeType = "unchanged"
 )
 
+// rsync에 넘기는 임시 파일 목록 이름 패턴 (os.CreateTemp 형식, 중단되면 임시 디렉토리에 남음)
+const (
+	filesListPattern  = "sync-files-*.txt"
+	pathsListPattern  = "sync-paths-*.txt"
+	verifyListPattern = "sync-verify-*.txt"
+)
+
+// TempLists 임시 디렉토리에 남아 있는 파일 목록 (clean에서 정리)
+func TempLists() []string {
+	lists := []string{}
+	for _, pattern := range []string{filesListPattern, pathsListPattern, verifyListPattern} {
+		matches, _ := filepath.Glob(filepath.Join(os.TempDir(), pattern))
+		lists = append(lists, matches...)
+	}
+	return lists
+}
+
 // FileChange 파일 변경 정보
 type FileChange struct {
 	Type     ChangeType
@@ -33,35 +54,121 @@ type FileChange struct {
 type SyncResult struct {
 	Changes      []FileChange
 	Deletions    []string
+	TotalBytes   int64
 	Error        error
 	HasChanges   bool
 	HasDeletions bool
+	Attempts     int // 파일 복사 시도 횟수 (재시도 포함)
+	Copied       int // 복사를 마친 파일 수
+	Removed      int // 삭제를 마친 파일 수
 }
 
 // SyncEngine 동기화 엔진
 type SyncEngine struct {
-	config *config.Config
+	config    *config.Config
+	useCache  bool
+	output    io.Writer
+	bwlimit   string
+	journal   *state.Journal
+	backupDir string // 이번 동기화의 백업 회차 디렉토리 (백업을 사용하지 않으면 비움)
+	source    string // 이번 실행의 rsync 소스 (계획과 적용이 같은 소스를 쓰도록 한 번만 결정)
+	remote    bool
+	syncing   bool // Sync 실행 중 (재시도의 드라이런은 소스를 다시 정하지 않음)
 }
 
 // NewSyncEngine 새로운 동기화 엔진 생성
 func NewSyncEngine(cfg *config.Config) *SyncEngine {
 	return &SyncEngine{
-		config: cfg,
+		config:   cfg,
+		useCache: true,
+		output:   os.Stdout,
 	}
 }
 
+// SetOutput 진행 메시지와 rsync 출력을 보낼 대상 설정 (기본값: 표준 출력)
+func (s *SyncEngine) SetOutput(w io.Writer) {
+	s.output = w
+}
+
+// SetUseCache fetch 캐시 사용 여부 설정 (false면 항상 서버에서 직접 전송)
+func (s *SyncEngine) SetUseCache(useCache bool) {
+	s.useCache = useCache
+}
+
+// SetJournal 진행 상황을 기록할 저널 설정 (완료된 파일과 삭제가 한 줄씩 기록됨)
+func (s *SyncEngine) SetJournal(journal *state.Journal) {
+	s.journal = journal
+}
+
+// Journal 현재 설정된 저널 (없으면 nil)
+func (s *SyncEngine) Journal() *state.Journal {
+	return s.journal
+}
+
+// SetBandwidthLimit 이번 실행의 대역폭 제한 설정 (transfer.bwlimit보다 우선, 비우면 설정값 사용)
+func (s *SyncEngine) SetBandwidthLimit(limit string) {
+	s.bwlimit = limit
+}
+
 // DryRun 실제 동기화 없이 변경사항만 확인
-func (s *SyncEngine) DryRun(profile *config.SyncProfile) (*SyncResult, error) {
+func (s *SyncEngine) DryRun(ctx context.Context, profile *config.SyncProfile) (*SyncResult, error) {
 	logger.Debugf("드라이런 시작: 프로필=%s, 서버경로=%s, 로컬경로=%s",
 		profile.Name, profile.ServerPath, profile.LocalPath)
 
+	// 새 계획이면 소스(캐시/서버)를 다시 결정
+	if !s.syncing {
+		s.source = ""
+	}
+
 	// rsync 명령어 구성
-	cmd := s.buildRsyncCommand(profile, true)
+	cmd := s.buildRsyncCommand(ctx, profile, true)
+	return s.runDryRun(ctx, profile, cmd)
+}
 
+// DryRunPaths 지정한 파일이나 디렉토리 아래만 드라이런 (paths는 대상 기준 상대 경로, 삭제는 계획하지 않음)
+func (s *SyncEngine) DryRunPaths(ctx context.Context, profile *config.SyncProfile, paths []string) (*SyncResult, error) {
+	logger.Debugf("경로 드라이런 시작: 프로필=%s, 경로=%v", profile.Name, paths)
+
+	if !s.syncing {
+		s.source = ""
+	}
+
+	tmpFile, err := os.CreateTemp("", pathsListPattern)
+	if err != nil {
+		return nil, fmt.Errorf("임시 파일 생성 실패: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	for _, path := range paths {
+		fmt.Fprintln(tmpFile, path)
+	}
+	tmpFile.Close()
+
+	source, remote := s.resolveSource(profile)
+
+	// --files-from은 -a의 재귀를 끄므로 디렉토리 아래까지 보도록 -r을 명시
+	args := []string{}
+	args = append(args, profile.GetSyncOptions(s.config.Sync.Options)...)
+	args = append(args, "--dry-run", "--stats", "--files-from", tmpFile.Name(), "--recursive")
+	args = append(args, "--no-perms", "--no-owner", "--no-group")
+	args = append(args, s.transferArgs(profile)...)
+	if remote {
+		args = append(args, "-e", s.sshCommand(profile))
+	}
+	args = append(args, s.filterArgs(profile)...)
+	args = append(args, source, profile.LocalPath+"/")
+
+	return s.runDryRun(ctx, profile, s.rsyncCommand(ctx, profile, args))
+}
+
+// runDryRun 드라이런 명령 실행 후 출력을 계획으로 파싱
+func (s *SyncEngine) runDryRun(ctx context.Context, profile *config.SyncProfile, cmd *exec.Cmd) (*SyncResult, error) {
 	logger.Debugf("실행할 rsync 명령어: %s", strings.Join(cmd.Args, " "))
 
 	// 명령어 실행 (드라이런은 CombinedOutput 사용)
 	output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("rsync 드라이런 중단: %w", ctx.Err())
+	}
 	if err != nil {
 		logger.Errorf("rsync 드라이런 실행 실패: %v", err)
 		logger.Errorf("rsync 출력: %s", string(output))
@@ -74,26 +181,52 @@ func (s *SyncEngine) DryRun(profile *config.SyncProfile) (*SyncResult, error) {
 	// 출력 파싱
 	result := s.parseRsyncOutput(string(output))
 
+	// 로컬 트리의 .syncignore로 제외된 파일은 덮어쓰거나 삭제하지 않음
+	s.applyLocalIgnores(profile, result)
+
 	logger.Infof("드라이런 완료: 변경파일=%d개, 삭제파일=%d개",
 		len(result.Changes), len(result.Deletions))
 
 	return result, nil
 }
 
-// Sync 실제 동기화 실행
-func (s *SyncEngine) Sync(profile *config.SyncProfile, changes *SyncResult) error {
+// Sync 실제 동기화 실행 (ctx가 취소되면 진행 중인 rsync를 정리하고 남은 작업은 건너뜀)
+func (s *SyncEngine) Sync(ctx context.Context, profile *config.SyncProfile, changes *SyncResult) error {
 	logger.Infof("동기화 시작: 프로필=%s", profile.Name)
 
+	// 계획에서 정한 소스를 적용이 끝날 때까지 유지
+	s.syncing = true
+	defer func() {
+		s.syncing = false
+		s.source = ""
+	}()
+
+	// 덮어쓰거나 삭제할 파일을 보관할 백업 회차
+	s.startBackup(profile)
+	if s.backupDir != "" {
+		defer func() {
+			if err := s.PruneBackups(profile); err != nil {
+				logger.Warnf("백업 정리 실패: %v", err)
+			}
+		}()
+	}
+
 	// 복사할 파일이 있는 경우
 	if len(changes.Changes) > 0 {
-		if err := s.syncFiles(profile, changes.Changes); err != nil {
+		if err := s.syncWithRetry(ctx, profile, changes); err != nil {
 			return fmt.Errorf("파일 동기화 실패: %w", err)
 		}
+		// 스테이징 모드면 모두 전송된 뒤 한 번에 제자리로 이동
+		if profile.Staging {
+			if err := s.commitStaged(ctx, profile, changes); err != nil {
+				return fmt.Errorf("스테이징 반영 실패: %w", err)
+			}
+		}
 	}
 
 	// 삭제할 파일이 있는 경우
 	if len(changes.Deletions) > 0 {
-		if err := s.deleteFiles(profile, changes.Deletions); err != nil {
+		if err := s.deleteFiles(ctx, profile, changes); err != nil {
 			return fmt.Errorf("파일 삭제 실패: %w", err)
 		}
 	}
@@ -103,57 +236,110 @@ func (s *SyncEngine) Sync(profile *config.SyncProfile, changes *SyncResult) erro
 }
 
 // buildRsyncCommand rsync 명령어 구성
-func (s *SyncEngine) buildRsyncCommand(profile *config.SyncProfile, dryRun bool) *exec.Cmd {
+func (s *SyncEngine) buildRsyncCommand(ctx context.Context, profile *config.SyncProfile, dryRun bool) *exec.Cmd {
+	// 소스 (유효한 캐시가 있으면 로컬 캐시)
+	source, remote := s.resolveSource(profile)
+
+	args := s.RsyncOptions(profile, dryRun, remote)
+
+	// 소스와 대상
+	target := fmt.Sprintf("%s/", profile.LocalPath)
+	args = append(args, source, target)
+
+	// 디버그: 생성된 rsync 명령어 로깅
+	logger.Debugf("생성된 rsync 명령어: rsync %s", strings.Join(args, " "))
+
+	return s.rsyncCommand(ctx, profile, args)
+}
+
+// RsyncOptions 전체 동기화에 사용하는 rsync 옵션 (소스와 대상 제외, remote면 SSH 옵션 포함)
+func (s *SyncEngine) RsyncOptions(profile *config.SyncProfile, dryRun, remote bool) []string {
 	args := []string{}
 
 	// 기본 옵션
 	options := profile.GetSyncOptions(s.config.Sync.Options)
 	args = append(args, options...)
 
-	// 드라이런 옵션
+	// 드라이런 옵션 (전송 크기 집계를 위해 통계 포함)
 	if dryRun {
-		args = append(args, "--dry-run")
+		args = append(args, "--dry-run", "--stats")
 	}
 
 	// 권한 관련 옵션
 	args = append(args, "--no-perms", "--no-owner", "--no-group")
 
-	// 안정성을 위한 추가 옵션
-	args = append(args, "--partial", "--partial-dir=.rsync-partial")
-	args = append(args, "--timeout=300") // 5분 타임아웃
+	// 부분 전송, 타임아웃, 대역폭, 압축 (transfer 설정)
+	args = append(args, s.transferArgs(profile)...)
 
 	// 삭제 옵션 (드라이런에서도 삭제 확인)
 	args = append(args, "--delete")
 
+	// 중단된 스테이징 전송은 다음 실행에서 이어 쓰므로 삭제 대상에서 제외
+	args = append(args, "--filter=P /"+StagingDir+"/")
+	args = append(args, backupFilter(profile)...)
+
 	// SSH 옵션
-	sshArgs := fmt.Sprintf("ssh -p %d", s.config.Server.Port)
+	if remote {
+		args = append(args, "-e", s.sshCommand(profile))
+	}
+
+	// 제외/포함 패턴
+	args = append(args, s.filterArgs(profile)...)
+
+	return args
+}
+
+// sshCommand rsync -e 옵션에 사용할 SSH 명령어
+func (s *SyncEngine) sshCommand(profile *config.SyncProfile) string {
+	sshArgs := s.PortableSSHCommand(profile)
 	if s.config.Server.KeyPath != "" {
 		sshArgs += fmt.Sprintf(" -i %s", s.config.Server.KeyPath)
 	}
-	args = append(args, "-e", sshArgs)
+	return sshArgs
+}
 
-	// 제외/포함 패턴
-	excludes := profile.GetExcludes(s.config.Sync.DefaultExcludes)
-	logger.Debugf("기본 제외 패턴: %v", s.config.Sync.DefaultExcludes)
-	logger.Debugf("프로필 제외 패턴: %v", profile.Excludes)
-	logger.Debugf("최종 제외 패턴: %v", excludes)
-	for _, exclude := range excludes {
-		args = append(args, "--exclude", exclude)
+// PortableSSHCommand 키 경로처럼 이 호스트에만 해당하는 값을 뺀 SSH 명령어 (다른 호스트에서 실행할 스크립트용)
+func (s *SyncEngine) PortableSSHCommand(profile *config.SyncProfile) string {
+	sshArgs := fmt.Sprintf("ssh -p %d", s.config.Server.Port)
+	if seconds := durationSeconds(s.transfer(profile).ConTimeout); seconds > 0 {
+		sshArgs += fmt.Sprintf(" -o ConnectTimeout=%d", seconds)
 	}
+	return sshArgs
+}
+
+// RemoteSource 서버 경로를 rsync 원격 소스 형식으로 반환
+func (s *SyncEngine) RemoteSource(profile *config.SyncProfile) string {
+	return fmt.Sprintf("%s@%s:%s/", s.config.Server.User, s.config.Server.Host, profile.ServerPath)
+}
 
-	for _, include := range profile.Includes {
-		args = append(args, "--include", include)
+// resolveSource rsync 소스 경로와 원격 여부 반환
+// 드라이런에서 정한 소스를 Sync가 끝날 때까지 유지하여, 그 사이 캐시가 만료되어도 계획과 같은 소스에서 복사
+func (s *SyncEngine) resolveSource(profile *config.SyncProfile) (string, bool) {
+	if s.source != "" {
+		return s.source, s.remote
 	}
 
-	// 소스와 대상
-	source := fmt.Sprintf("%s@%s:%s/", s.config.Server.User, s.config.Server.Host, profile.ServerPath)
-	target := fmt.Sprintf("%s/", profile.LocalPath)
-	args = append(args, source, target)
+	s.source, s.remote = s.RemoteSource(profile), true
+	if s.useCache {
+		if info, fresh := s.freshCache(profile); fresh {
+			logger.Infof("로컬 캐시 사용: %s (fetch 시각: %s)",
+				s.cacheDataDir(profile), info.FetchedAt.Format("2006-01-02 15:04:05"))
+			s.source, s.remote = s.cacheDataDir(profile)+"/", false
+		}
+	}
+	return s.source, s.remote
+}
 
-	// 디버그: 생성된 rsync 명령어 로깅
-	logger.Debugf("생성된 rsync 명령어: rsync %s", strings.Join(args, " "))
+// filterArgs 필터 규칙을 순서대로 rsync --filter 인자로 변환
+func (s *SyncEngine) filterArgs(profile *config.SyncProfile) []string {
+	args := []string{}
+
+	for _, rule := range s.FilterRules(profile) {
+		logger.Debugf("필터 규칙 (%s): %s", rule.Source, rule.String())
+		args = append(args, "--filter="+rule.String())
+	}
 
-	return exec.Command("rsync", args...)
+	return args
 }
 
 // parseRsyncOutput rsync 출력 파싱
@@ -173,6 +359,12 @@ func (s *SyncEngine) parseRsyncOutput(output string) *SyncResult {
 		// rsync 출력 형식 파싱
 		// 예: >f+++++++++ file.txt 또는 *deleting file.txt
 
+		// 전송 크기 통계 (예: Total transferred file size: 1,234 bytes)
+		if strings.HasPrefix(line, "Total transferred file size:") {
+			result.TotalBytes = parseStatBytes(line)
+			continue
+		}
+
 		// 삭제 파일 처리
 		if strings.HasPrefix(line, "*deleting") {
 			path := strings.TrimSpace(line[9:]) // "*deleting " 제거
@@ -234,12 +426,53 @@ func (s *SyncEngine) parseRsyncOutput(output string) *SyncResult {
 	return result
 }
 
-// syncFiles 파일 동기화
-func (s *SyncEngine) syncFiles(profile *config.SyncProfile, changes []FileChange) error {
+// applyLocalIgnores 로컬 트리의 .syncignore에 해당하는 변경/삭제 항목 제거
+func (s *SyncEngine) applyLocalIgnores(profile *config.SyncProfile, result *SyncResult) {
+	set := NewFilterSet([]FilterRule{syncIgnoreRule()}, profile.LocalPath)
+
+	changes := result.Changes[:0]
+	for _, change := range result.Changes {
+		if set.Decide(change.Path, strings.HasSuffix(change.Path, "/")).Included {
+			changes = append(changes, change)
+		} else {
+			logger.Infof("로컬 %s로 제외되어 건너뜀: %s", SyncIgnoreFile, change.Path)
+		}
+	}
+	result.Changes = changes
+
+	deletions := result.Deletions[:0]
+	for _, deletion := range result.Deletions {
+		if set.Decide(deletion, strings.HasSuffix(deletion, "/")).Included {
+			deletions = append(deletions, deletion)
+		} else {
+			logger.Infof("로컬 %s로 보호되어 삭제하지 않음: %s", SyncIgnoreFile, deletion)
+		}
+	}
+	result.Deletions = deletions
+
+	result.HasChanges = len(result.Changes) > 0
+	result.HasDeletions = len(result.Deletions) > 0
+}
+
+// parseStatBytes rsync --stats 라인에서 바이트 수 추출
+func parseStatBytes(line string) int64 {
+	fields := strings.Fields(strings.TrimSpace(line[strings.Index(line, ":")+1:]))
+	if len(fields) == 0 {
+		return 0
+	}
+	value, err := strconv.ParseInt(strings.ReplaceAll(fields[0], ",", ""), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// syncFiles 파일 동기화 (복사를 마친 파일마다 done 호출)
+func (s *SyncEngine) syncFiles(ctx context.Context, profile *config.SyncProfile, changes []FileChange, done func(path string)) error {
 	logger.Infof("파일 복사 시작: %d개 파일", len(changes))
 
 	// 변경된 파일 목록을 임시 파일로 저장
-	tmpFile, err := os.CreateTemp("", "sync-files-*.txt")
+	tmpFile, err := os.CreateTemp("", filesListPattern)
 	if err != nil {
 		return fmt.Errorf("임시 파일 생성 실패: %w", err)
 	}
@@ -253,21 +486,26 @@ func (s *SyncEngine) syncFiles(profile *config.SyncProfile, changes []FileChange
 	tmpFile.Close()
 
 	// rsync 명령어 구성 (파일 목록 사용)
-	cmd := s.buildRsyncCommandWithFileList(profile, tmpFile.Name())
+	cmd := s.buildRsyncCommandWithFileList(ctx, profile, tmpFile.Name())
 
 	logger.Debugf("파일 복사 명령어: %s", strings.Join(cmd.Args, " "))
 
 	// 진행률 표시기 생성
 	progress := NewSimpleProgress(len(changes))
+	progress.Output = s.output
 
-	// 실시간 출력을 위해 stdout/stderr을 터미널에 연결
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	// 실시간 출력을 위해 stdout/stderr을 출력 대상에 연결 (저널이 있으면 완료된 파일 기록)
+	tracker := newItemizeTracker(s.output, done)
+	cmd.Stdout = tracker
+	cmd.Stderr = s.output
 
 	// 시작 메시지
-	fmt.Printf("\n🔄 파일 동기화 진행 중...\n")
-	fmt.Printf("📁 대상: %s\n", profile.LocalPath)
-	fmt.Printf("📊 총 파일: %d개\n\n", len(changes))
+	fmt.Fprintf(s.output, "\n🔄 파일 동기화 진행 중...\n")
+	fmt.Fprintf(s.output, "📁 대상: %s\n", profile.LocalPath)
+	if profile.Staging {
+		fmt.Fprintf(s.output, "📦 스테이징: %s (전송이 끝나면 한 번에 반영)\n", s.stagingPath(profile))
+	}
+	fmt.Fprintf(s.output, "📊 총 파일: %d개\n\n", len(changes))
 
 	// 진행률 표시 시작
 	progress.Update(0, "시작...")
@@ -277,49 +515,59 @@ func (s *SyncEngine) syncFiles(profile *config.SyncProfile, changes []FileChange
 	}
 
 	if err := cmd.Wait(); err != nil {
+		// 중단 요청으로 종료된 경우 (전송 중이던 파일은 partial_dir에 남아 재개 시 이어받음)
+		if ctx.Err() != nil {
+			return fmt.Errorf("파일 복사 중단: %w", ctx.Err())
+		}
 		// rsync exit status 코드에 따른 에러 메시지
 		if exitError, ok := err.(*exec.ExitError); ok {
-			switch exitError.ExitCode() {
-			case 23:
-				return fmt.Errorf("rsync 부분 실패 (일부 파일 전송 실패)")
-			case 24:
-				return fmt.Errorf("rsync 일시적 실패 (재시도 필요)")
-			default:
-				return fmt.Errorf("rsync 실행 실패 (exit code %d)", exitError.ExitCode())
-			}
+			return &RsyncError{ExitCode: exitError.ExitCode()}
 		}
 		return fmt.Errorf("파일 복사 실행 실패: %w", err)
 	}
+	tracker.Finish()
 
 	// 진행률 완료 표시
 	progress.Complete()
 
-	fmt.Printf("📁 대상 경로: %s\n", profile.LocalPath)
+	fmt.Fprintf(s.output, "📁 대상 경로: %s\n", profile.LocalPath)
 	logger.Infof("파일 복사 완료: %s", profile.LocalPath)
 
 	return nil
 }
 
-// deleteFiles 파일 삭제
-func (s *SyncEngine) deleteFiles(profile *config.SyncProfile, deletions []string) error {
-	logger.Infof("로컬 파일 삭제 시작: %d개 파일", len(deletions))
+// deleteFiles 파일 삭제 (삭제를 마칠 때마다 changes.Removed 증가)
+func (s *SyncEngine) deleteFiles(ctx context.Context, profile *config.SyncProfile, changes *SyncResult) error {
+	logger.Infof("로컬 파일 삭제 시작: %d개 파일", len(changes.Deletions))
+
+	for _, filePath := range changes.Deletions {
+		if ctx.Err() != nil {
+			return fmt.Errorf("파일 삭제 중단: %w", ctx.Err())
+		}
 
-	for _, filePath := range deletions {
 		fullPath := filepath.Join(profile.LocalPath, filePath)
 
 		// 파일 존재 확인
 		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
 			logger.Warnf("삭제할 파일이 존재하지 않음: %s", fullPath)
+			s.markDeleted(filePath)
+			changes.Removed++
 			continue
 		}
 
-		// 파일/디렉토리 삭제
-		if err := os.RemoveAll(fullPath); err != nil {
+		// 파일/디렉토리 삭제 (백업을 사용하면 백업 회차로 이동)
+		remove := os.RemoveAll
+		if s.backupDir != "" {
+			remove = func(path string) error { return s.backupMove(path, filePath) }
+		}
+		if err := remove(fullPath); err != nil {
 			logger.Errorf("파일 삭제 실패: %s, 오류: %v", fullPath, err)
 			continue
 		}
 
 		logger.Infof("파일 삭제됨: %s", fullPath)
+		s.markDeleted(filePath)
+		changes.Removed++
 	}
 
 	logger.Info("로컬 파일 삭제 완료")
@@ -327,7 +575,7 @@ func (s *SyncEngine) deleteFiles(profile *config.SyncProfile, deletions []string
 }
 
 // buildRsyncCommandWithFileList 파일 목록을 사용한 rsync 명령어 구성
-func (s *SyncEngine) buildRsyncCommandWithFileList(profile *config.SyncProfile, fileListPath string) *exec.Cmd {
+func (s *SyncEngine) buildRsyncCommandWithFileList(ctx context.Context, profile *config.SyncProfile, fileListPath string) *exec.Cmd {
 	args := []string{}
 
 	// 기본 옵션
@@ -340,23 +588,31 @@ func (s *SyncEngine) buildRsyncCommandWithFileList(profile *config.SyncProfile,
 	// 권한 관련 옵션
 	args = append(args, "--no-perms", "--no-owner", "--no-group")
 
-	// 안정성을 위한 추가 옵션
-	args = append(args, "--partial", "--partial-dir=.rsync-partial")
-	args = append(args, "--timeout=300") // 5분 타임아웃
+	// 부분 전송, 타임아웃, 대역폭, 압축 (transfer 설정)
+	args = append(args, s.transferArgs(profile)...)
+
+	// 소스 (유효한 캐시가 있으면 로컬 캐시)
+	source, remote := s.resolveSource(profile)
 
 	// SSH 옵션
-	sshArgs := fmt.Sprintf("ssh -p %d", s.config.Server.Port)
-	if s.config.Server.KeyPath != "" {
-		sshArgs += fmt.Sprintf(" -i %s", s.config.Server.KeyPath)
+	if remote {
+		args = append(args, "-e", s.sshCommand(profile))
 	}
-	args = append(args, "-e", sshArgs)
 
-	// 소스와 대상
-	source := fmt.Sprintf("%s@%s:%s/", s.config.Server.User, s.config.Server.Host, profile.ServerPath)
+	// 스테이징 모드는 숨김 디렉토리로 받고, 기존 파일을 델타 전송의 기준으로 사용
 	target := fmt.Sprintf("%s/", profile.LocalPath)
+	if profile.Staging {
+		args = append(args, "--copy-dest="+profile.LocalPath)
+		target = s.stagingPath(profile) + "/"
+	} else {
+		// 덮어쓰는 파일은 백업 회차로 이동
+		args = append(args, s.backupArgs()...)
+	}
+
+	// 소스와 대상
 	args = append(args, source, target)
 
-	return exec.Command("rsync", args...)
+	return s.rsyncCommand(ctx, profile, args)
 }
 
 // ValidateProfile 프로필 유효성 검사
@@ -366,6 +622,11 @@ func (s *SyncEngine) ValidateProfile(profile *config.SyncProfile) error {
 		return fmt.Errorf("로컬 경로가 존재하지 않습니다: %s", profile.LocalPath)
 	}
 
+	return s.ValidateSettings(profile)
+}
+
+// ValidateSettings 대상 경로를 제외한 서버, 필터, 전송, 백업 설정 검사
+func (s *SyncEngine) ValidateSettings(profile *config.SyncProfile) error {
 	// 서버 정보 확인
 	if s.config.Server.Host == "" {
 		return fmt.Errorf("서버 호스트가 설정되지 않았습니다")
@@ -375,5 +636,20 @@ func (s *SyncEngine) ValidateProfile(profile *config.SyncProfile) error {
 		return fmt.Errorf("서버 사용자가 설정되지 않았습니다")
 	}
 
+	// 필터 규칙 확인
+	if err := validateFilters(profile); err != nil {
+		return err
+	}
+
+	// 전송 설정 확인
+	if err := s.validateTransfer(profile); err != nil {
+		return err
+	}
+
+	// 백업 설정 확인
+	if err := validateBackup(profile); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,176 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"sync-tool/internal/config"
+	"sync-tool/internal/logger"
+)
+
+// interruptGrace 중단 요청 후 rsync가 정리하고 종료할 때까지 기다리는 시간 (넘으면 강제 종료)
+const interruptGrace = 30 * time.Second
+
+// transfer 프로필 전송 설정 (실행 시 지정한 대역폭 제한이 우선)
+func (s *SyncEngine) transfer(profile *config.SyncProfile) config.TransferConfig {
+	transfer := profile.GetTransfer(s.config.Sync.Transfer)
+	if s.bwlimit != "" {
+		transfer.BWLimit = s.bwlimit
+	}
+	return transfer
+}
+
+// PartialDir 프로필의 부분 전송 파일 디렉토리 이름
+func (s *SyncEngine) PartialDir(profile *config.SyncProfile) string {
+	return s.transfer(profile).PartialDir
+}
+
+// transferArgs 부분 전송, 타임아웃, 대역폭, 압축 관련 rsync 인자
+func (s *SyncEngine) transferArgs(profile *config.SyncProfile) []string {
+	transfer := s.transfer(profile)
+
+	args := []string{"--partial", "--partial-dir=" + transfer.PartialDir}
+	if seconds := durationSeconds(transfer.Timeout); seconds > 0 {
+		args = append(args, fmt.Sprintf("--timeout=%d", seconds))
+	}
+	if transfer.BWLimit != "" {
+		args = append(args, "--bwlimit="+transfer.BWLimit)
+	}
+	if transfer.CompressLevel != nil {
+		if *transfer.CompressLevel > 0 {
+			args = append(args, "--compress")
+		}
+		args = append(args, fmt.Sprintf("--compress-level=%d", *transfer.CompressLevel))
+	}
+	return args
+}
+
+// rsyncCommand nice/ionice 우선순위를 적용한 rsync 명령어 생성
+// ctx가 취소되면 바로 죽이지 않고 SIGINT를 보내 rsync가 부분 전송 파일을 정리하고 종료하도록 함
+func (s *SyncEngine) rsyncCommand(ctx context.Context, profile *config.SyncProfile, args []string) *exec.Cmd {
+	command := []string{}
+
+	ionice, nice := s.PriorityArgs(profile)
+	if len(ionice) > 0 {
+		if runtime.GOOS != "linux" {
+			logger.Debugf("ionice는 Linux에서만 지원되어 무시합니다")
+		} else if _, err := exec.LookPath("ionice"); err != nil {
+			logger.Warnf("ionice를 찾을 수 없어 I/O 우선순위 설정을 건너뜁니다")
+		} else {
+			command = append(command, ionice...)
+		}
+	}
+	command = append(command, nice...)
+	command = append(command, "rsync")
+	command = append(command, args...)
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Cancel = func() error {
+		// Windows처럼 SIGINT를 보낼 수 없으면 바로 종료
+		if err := cmd.Process.Signal(os.Interrupt); err != nil {
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+	cmd.WaitDelay = interruptGrace
+	return cmd
+}
+
+// PriorityArgs rsync 앞에 붙일 ionice와 nice 명령어 (설정하지 않았거나 잘못된 값이면 비움)
+func (s *SyncEngine) PriorityArgs(profile *config.SyncProfile) (ionice, nice []string) {
+	transfer := s.transfer(profile)
+
+	if transfer.IONice != "" {
+		class, level, err := parseIONice(transfer.IONice)
+		if err != nil {
+			logger.Warnf("ionice 설정 무시: %v", err)
+		} else {
+			ionice = []string{"ionice", "-c", class}
+			if level != "" {
+				ionice = append(ionice, "-n", level)
+			}
+		}
+	}
+	if transfer.Nice != nil && *transfer.Nice != 0 {
+		nice = []string{"nice", "-n", strconv.Itoa(*transfer.Nice)}
+	}
+	return ionice, nice
+}
+
+// validateTransfer 전송 설정 값 확인
+func (s *SyncEngine) validateTransfer(profile *config.SyncProfile) error {
+	transfer := s.transfer(profile)
+
+	if _, err := config.ParseDuration(transfer.Timeout); err != nil {
+		return fmt.Errorf("transfer.timeout 오류: %w", err)
+	}
+	if _, err := config.ParseDuration(transfer.ConTimeout); err != nil {
+		return fmt.Errorf("transfer.contimeout 오류: %w", err)
+	}
+	if transfer.Nice != nil && (*transfer.Nice < -20 || *transfer.Nice > 19) {
+		return fmt.Errorf("transfer.nice는 -20에서 19 사이여야 합니다: %d", *transfer.Nice)
+	}
+	if transfer.IONice != "" {
+		if _, _, err := parseIONice(transfer.IONice); err != nil {
+			return err
+		}
+	}
+	if transfer.CompressLevel != nil && *transfer.CompressLevel < 0 {
+		return fmt.Errorf("transfer.compress_level은 0 이상이어야 합니다: %d", *transfer.CompressLevel)
+	}
+	if transfer.Retry.MaxAttempts < 1 {
+		return fmt.Errorf("transfer.retry.max_attempts는 1 이상이어야 합니다: %d", transfer.Retry.MaxAttempts)
+	}
+	if _, err := config.ParseDuration(transfer.Retry.Backoff); err != nil {
+		return fmt.Errorf("transfer.retry.backoff 오류: %w", err)
+	}
+	if _, err := config.ParseDuration(transfer.Retry.MaxBackoff); err != nil {
+		return fmt.Errorf("transfer.retry.max_backoff 오류: %w", err)
+	}
+	if transfer.PartialDir == "" || strings.Contains(transfer.PartialDir, "..") {
+		return fmt.Errorf("transfer.partial_dir이 잘못되었습니다: %q", transfer.PartialDir)
+	}
+	return nil
+}
+
+// parseIONice ionice 설정을 클래스와 레벨로 변환 (idle, best-effort[:0-7], realtime[:0-7])
+func parseIONice(value string) (class, level string, err error) {
+	name, levelText, hasLevel := strings.Cut(strings.TrimSpace(value), ":")
+	switch name {
+	case "idle":
+		if hasLevel {
+			return "", "", fmt.Errorf("ionice idle 클래스에는 레벨을 지정할 수 없습니다: %s", value)
+		}
+		return "3", "", nil
+	case "best-effort":
+		class = "2"
+	case "realtime":
+		class = "1"
+	default:
+		return "", "", fmt.Errorf("잘못된 ionice 설정: %s (idle, best-effort:N, realtime:N)", value)
+	}
+
+	if hasLevel {
+		n, err := strconv.Atoi(levelText)
+		if err != nil || n < 0 || n > 7 {
+			return "", "", fmt.Errorf("ionice 레벨은 0에서 7 사이여야 합니다: %s", value)
+		}
+		level = levelText
+	}
+	return class, level, nil
+}
+
+// durationSeconds 기간 문자열을 초 단위로 변환 (비었거나 잘못된 값이면 0)
+func durationSeconds(value string) int {
+	duration, err := config.ParseDuration(value)
+	if err != nil {
+		return 0
+	}
+	return int(duration.Seconds())
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"sync-tool/internal/app"
 	"sync-tool/internal/config"
@@ -32,6 +33,22 @@ func main() {
 	rootCmd.AddCommand(statusCmd())
 	rootCmd.AddCommand(syncCmd())
 	rootCmd.AddCommand(profilesCmd())
+	rootCmd.AddCommand(fetchCmd())
+	rootCmd.AddCommand(lintCmd())
+	rootCmd.AddCommand(filterCmd())
+	rootCmd.AddCommand(ejectCmd())
+	rootCmd.AddCommand(devicesCmd())
+	rootCmd.AddCommand(watchDevicesCmd())
+	rootCmd.AddCommand(watchCmd())
+	rootCmd.AddCommand(daemonCmd())
+	rootCmd.AddCommand(cleanCmd())
+	rootCmd.AddCommand(restoreCmd())
+	rootCmd.AddCommand(tagCmd())
+	rootCmd.AddCommand(checkoutCmd())
+	rootCmd.AddCommand(collectCmd())
+	rootCmd.AddCommand(applyBundleCmd())
+	rootCmd.AddCommand(exportCmd())
+	rootCmd.AddCommand(scriptCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "오류 발생: %v\n", err)
@@ -65,8 +82,7 @@ func statusCmd() *cobra.Command {
 
 func syncCmd() *cobra.Command {
 	var profile string
-	var dryRun bool
-	var autoConfirm bool
+	var opts app.SyncOptions
 	var useTUI bool
 
 	cmd := &cobra.Command{
@@ -88,13 +104,20 @@ func syncCmd() *cobra.Command {
 				return app.ShowTUI(cfg, profile)
 			}
 
-			return app.Sync(cfg, profile, dryRun, autoConfirm)
+			return app.Sync(cfg, profile, opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", "", "사용할 프로필명")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "실제 동기화 없이 변경사항만 확인")
-	cmd.Flags().BoolVar(&autoConfirm, "yes", false, "확인 없이 자동 실행")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "실제 동기화 없이 변경사항만 확인")
+	cmd.Flags().BoolVar(&opts.AutoConfirm, "yes", false, "확인 없이 자동 실행")
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "fetch 캐시를 무시하고 서버에서 직접 동기화")
+	cmd.Flags().StringSliceVar(&opts.Targets, "targets", nil, "동기화할 대상 경로 목록 (쉼표 구분, 글롭 패턴 허용)")
+	cmd.Flags().IntVar(&opts.Jobs, "jobs", 0, "동시에 동기화할 최대 대상 수 (기본값: 4)")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "모든 프로필 동기화")
+	cmd.Flags().StringVar(&opts.Group, "group", "", "지정한 프로필 그룹 동기화")
+	cmd.Flags().BoolVar(&opts.Resume, "resume", false, "중단된 동기화를 다시 계획하지 않고 이어서 실행")
+	cmd.Flags().StringVar(&opts.BWLimit, "bwlimit", "", "이번 실행의 대역폭 제한 (rsync --bwlimit 값, transfer.bwlimit보다 우선)")
 	cmd.Flags().BoolVar(&useTUI, "tui", false, "TUI 인터페이스 사용")
 
 	return cmd
@@ -114,6 +137,394 @@ func profilesCmd() *cobra.Command {
 	}
 }
 
+func fetchCmd() *cobra.Command {
+	var opts app.FetchOptions
+
+	cmd := &cobra.Command{
+		Use:   "fetch <프로필명>",
+		Short: "서버 경로를 로컬 캐시로 미러링",
+		Long:  "서버 경로를 로컬 캐시에 한 번 내려받아, 이후 동기화가 네트워크 대신 캐시에서 복사하도록 합니다.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.Fetch(cfg, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.BWLimit, "bwlimit", "", "이번 fetch의 대역폭 제한 (rsync --bwlimit 값, transfer.bwlimit보다 우선)")
+
+	return cmd
+}
+
+func lintCmd() *cobra.Command {
+	var withPlan bool
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "설정 검사 (대상 경로가 겹치는 프로필 탐지)",
+		Long:  "로컬 경로가 겹치는 프로필을 찾고, --plan 사용 시 각 프로필이 서로의 파일을 삭제하는지 드라이런으로 확인합니다.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.Lint(cfg, withPlan)
+		},
+	}
+
+	cmd.Flags().BoolVar(&withPlan, "plan", false, "드라이런으로 서로 삭제하는 파일 확인")
+
+	return cmd
+}
+
+func filterCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "filter",
+		Short: "필터 규칙 도구",
+	}
+
+	var opts app.FilterTestOptions
+	testCmd := &cobra.Command{
+		Use:   "test <프로필명> [경로...]",
+		Short: "경로가 포함/제외되는 이유 확인",
+		Long:  "각 경로에 일치한 필터 규칙(default_excludes, filters, includes/excludes, merge 파일)과 최종 판정을 표시합니다.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.FilterTest(cfg, args[0], args[1:], opts)
+		},
+	}
+
+	testCmd.Flags().BoolVar(&opts.Walk, "walk", false, "실제 트리를 순회하며 전송될 파일 목록 표시")
+	testCmd.Flags().StringVar(&opts.Root, "root", "", "순회할 트리 (기본값: 유효한 fetch 캐시, 없으면 로컬 경로)")
+	testCmd.Flags().BoolVar(&opts.ShowExcluded, "excluded", false, "--walk에서 제외된 항목과 이유도 표시")
+
+	cmd.AddCommand(testCmd)
+	return cmd
+}
+
+func ejectCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "eject <프로필명>",
+		Short: "USB 버퍼 플러시 후 안전하게 분리",
+		Long:  "프로필 대상 볼륨의 버퍼를 디스크에 기록하고, 열린 파일이 없는지 확인한 뒤 마운트를 해제합니다.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.Eject(cfg, args[0])
+		},
+	}
+}
+
+func devicesCmd() *cobra.Command {
+	var opts app.DevicesOptions
+
+	cmd := &cobra.Command{
+		Use:   "devices",
+		Short: "연결된 USB 장치와 마지막 동기화 기록 표시",
+		Long:  "연결된 이동식 장치의 라벨, UUID, 시리얼, 용량, 파일시스템과 일치하는 프로필, 마지막 동기화 기록을 표시합니다.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.Devices(cfg, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Known, "known", false, "연결되지 않은 장치를 포함해 기록된 모든 장치 표시")
+	cmd.Flags().StringVar(&opts.StaleAfter, "stale", "30d", "마지막 동기화 후 이 기간이 지나면 경고 (0이면 사용 안 함)")
+
+	return cmd
+}
+
+func watchDevicesCmd() *cobra.Command {
+	var opts app.WatchDevicesOptions
+
+	cmd := &cobra.Command{
+		Use:   "watch-devices",
+		Short: "USB가 연결되면 프로필 자동 동기화",
+		Long:  "마운트 테이블을 주기적으로 확인하여 프로필의 device 조건과 일치하는 USB가 연결되면 auto 정책에 따라 동기화합니다.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.WatchDevices(cfg, opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.Interval, "interval", 2*time.Second, "마운트 테이블 확인 주기")
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "fetch 캐시를 사용하지 않고 서버에서 직접 동기화")
+
+	return cmd
+}
+
+func watchCmd() *cobra.Command {
+	var opts app.WatchOptions
+
+	cmd := &cobra.Command{
+		Use:   "watch <프로필명>",
+		Short: "프로필을 계속 서버와 일치하도록 자동 동기화",
+		Long:  "주기적으로 변경사항을 확인하고, 로컬 파일이 바뀌면 잠시 기다린 뒤 확인 없이 동기화합니다. 실패하면 대기 시간을 늘려 다시 시도합니다.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.Watch(cfg, args[0], opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.Interval, "interval", 5*time.Minute, "서버 변경사항 확인 주기")
+	cmd.Flags().DurationVar(&opts.Debounce, "debounce", 5*time.Second, "로컬 변경 후 동기화까지 대기 시간")
+	cmd.Flags().DurationVar(&opts.MaxBackoff, "max-backoff", time.Hour, "실패 시 최대 대기 시간")
+	cmd.Flags().BoolVar(&opts.UseCache, "use-cache", false, "서버 대신 fetch 캐시에서 동기화 (캐시는 fetch로 따로 갱신해야 함)")
+
+	return cmd
+}
+
+func daemonCmd() *cobra.Command {
+	var opts app.DaemonOptions
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "schedule이 설정된 프로필을 예약 실행",
+		Long:  "프로필의 schedule(cron, 허용 시간대, 주간/야간 대역폭 제한)에 따라 동기화를 실행합니다. 같은 대상에는 한 번에 하나의 작업만 실행하며, 실행 상태는 재시작 후에도 유지됩니다.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.Daemon(cfg, opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.Tick, "tick", 30*time.Second, "예약 확인 주기")
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "fetch 캐시를 사용하지 않고 서버에서 직접 동기화")
+
+	return cmd
+}
+
+func cleanCmd() *cobra.Command {
+	var opts app.CleanOptions
+
+	cmd := &cobra.Command{
+		Use:   "clean [프로필명]",
+		Short: "오래된 동기화 저널과 부분 전송 파일 정리",
+		Long:  "중단된 동기화가 남긴 저널, 대상 경로의 부분 전송 디렉토리, 임시 파일 목록 중 오래된 것을 삭제합니다. 재개 가능한 저널이 있는 대상의 부분 전송 파일은 유지합니다.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			profileName := ""
+			if len(args) > 0 {
+				profileName = args[0]
+			}
+			return app.Clean(cfg, profileName, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.OlderThan, "older-than", "24h", "이 기간 동안 갱신되지 않은 항목만 삭제 (0이면 모두)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "삭제하지 않고 대상만 표시")
+
+	return cmd
+}
+
+func restoreCmd() *cobra.Command {
+	var opts app.RestoreOptions
+
+	cmd := &cobra.Command{
+		Use:   "restore [프로필명] [경로]",
+		Short: "백업된 이전 버전 복원",
+		Long:  "프로필 backup 설정으로 보관된 이전 버전을 대상 경로에 복원합니다. 네트워크 없이 동작하며, 복원 전 파일도 백업됩니다.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.Restore(cfg, args[0], args[1], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.At, "at", "", "이 시각에 있던 버전으로 복원 (예: 2026-10-01, \"2026-10-01 09:00\", 2d)")
+	cmd.Flags().BoolVar(&opts.List, "list", false, "복원하지 않고 백업된 버전만 표시")
+
+	return cmd
+}
+
+func tagCmd() *cobra.Command {
+	var opts app.TagOptions
+
+	cmd := &cobra.Command{
+		Use:   "tag [프로필명] [태그]",
+		Short: "대상의 현재 상태를 태그로 기록",
+		Long:  "대상 경로의 파일 목록과 SHA-256 해시를 이름 붙은 스냅샷으로 저장합니다. 태그를 생략하면 태그 목록을 표시합니다.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			name := ""
+			if len(args) > 1 {
+				name = args[1]
+			}
+			return app.Tag(cfg, args[0], name, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "같은 이름의 태그가 있으면 덮어쓰기")
+
+	return cmd
+}
+
+func checkoutCmd() *cobra.Command {
+	var opts app.CheckoutOptions
+
+	cmd := &cobra.Command{
+		Use:   "checkout [프로필명] [태그] | checkout [프로필명] -- [경로...]",
+		Short: "대상을 태그 상태로 되돌리거나 일부 파일만 서버에서 받기",
+		Long: "태그에 기록된 파일과 정확히 같은 상태가 되도록 캐시와 백업에서 파일을 복원하고 태그에 없는 파일은 삭제합니다.\n" +
+			"-- 뒤에 경로를 지정하면 해당 파일이나 디렉토리만 서버에서 받고, 나머지 변경사항과 삭제는 건너뜁니다.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				if dash != 1 || len(args) < 2 {
+					return fmt.Errorf("사용법: checkout [프로필명] -- [경로...]")
+				}
+				return nil
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			if cmd.ArgsLenAtDash() >= 0 {
+				return app.CheckoutPaths(cfg, args[0], args[1:], opts)
+			}
+			return app.Checkout(cfg, args[0], args[1], opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "변경하지 않고 계획만 표시")
+	cmd.Flags().BoolVar(&opts.AutoConfirm, "yes", false, "확인 없이 자동 실행")
+
+	return cmd
+}
+
+func collectCmd() *cobra.Command {
+	var opts app.CollectOptions
+
+	cmd := &cobra.Command{
+		Use:   "collect [프로필명]",
+		Short: "대상에서 직접 고친 파일을 검토용 번들로 묶기",
+		Long:  "마지막 동기화 이후 대상에서 추가/수정/삭제된 파일과 변경 내역(bundle.json)을 tar.gz 번들로 저장합니다.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.Collect(cfg, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "번들 파일 경로 (예: changes.tar.gz)")
+
+	return cmd
+}
+
+func applyBundleCmd() *cobra.Command {
+	var opts app.ApplyBundleOptions
+
+	cmd := &cobra.Command{
+		Use:   "apply-bundle [번들] [서버 트리 경로]",
+		Short: "collect로 만든 번들을 서버 트리에 적용",
+		Long:  "번들의 변경을 서버 트리에 적용합니다. 경로를 생략하면 번들에 기록된 server_path를 사용하며, 동기화 이후 서버에서도 바뀐 파일이 있으면 적용하지 않습니다.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			dir := ""
+			if len(args) > 1 {
+				dir = args[1]
+			}
+			return app.ApplyBundle(cfg, args[0], dir, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "변경하지 않고 적용할 내용만 표시")
+	cmd.Flags().BoolVar(&opts.AutoConfirm, "yes", false, "확인 없이 자동 실행")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "충돌이 있어도 번들 내용으로 덮어쓰기")
+
+	return cmd
+}
+
+func exportCmd() *cobra.Command {
+	var opts app.ExportOptions
+
+	cmd := &cobra.Command{
+		Use:   "export [프로필명]",
+		Short: "프로필의 파일 집합을 아카이브나 디스크 이미지로 내보내기",
+		Long:  "빈 USB에 동기화할 때 받게 될 파일 전체(필터 적용)를 매니페스트와 함께 tar, zip 또는 iso로 저장합니다.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.Export(cfg, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Format, "format", "tar", "출력 형식 (tar, zip, iso; tar는 .gz/.tgz 이름이면 압축)")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "출력 파일 경로")
+	cmd.Flags().StringVar(&opts.WorkDir, "work-dir", "", "파일을 받아 둘 작업 디렉토리 위치 (기본값: 출력 파일이 있는 디렉토리)")
+	cmd.Flags().StringVar(&opts.Label, "label", "", "ISO 볼륨 라벨 (기본값: 장치 라벨 또는 프로필명)")
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "캐시를 사용하지 않고 서버에서 직접 받기")
+
+	return cmd
+}
+
+func scriptCmd() *cobra.Command {
+	var opts app.ScriptOptions
+
+	cmd := &cobra.Command{
+		Use:   "script [프로필명]",
+		Short: "프로필과 같은 동기화를 수행하는 셸 스크립트 생성",
+		Long:  "프로필의 rsync 옵션, 필터, 안전 확인과 설명 주석을 담은 POSIX 셸 스크립트를 출력합니다. sync-tool을 설치할 수 없는 호스트에서 검토 후 실행할 수 있습니다.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			return app.Script(cfg, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "스크립트 파일 경로 (기본값: 표준 출력)")
+
+	return cmd
+}
+
 func loadConfig() (*config.Config, error) {
 	viper.SetConfigFile(configFile)
 	viper.SetConfigType("yaml")
@@ -127,9 +538,7 @@ func loadConfig() (*config.Config, error) {
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("설정 파싱 실패: %w", err)
 	}
-
-	// 디버그: 설정 로딩 확인
-	fmt.Printf("디버그 - 기본 제외 패턴: %v\n", cfg.Sync.DefaultExcludes)
+	cfg.Normalize()
 
 	// verbose 플래그가 설정된 경우 로그 레벨 변경
 	if verbose {